@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestJWT(t *testing.T) {
+	j := NewJWT(testKey)
+	j.now = func() time.Time { return time.Unix(1000, 0) }
+	sign := func(c Claims) string {
+		token, err := j.Sign(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	valid := sign(Claims{Subject: "alice", Roles: []string{"admin"}, ExpiresAt: 2000})
+	forged, _ := NewJWT([]byte("another key, just as long as the first")).Sign(Claims{Subject: "alice"})
+	parts := strings.Split(valid, ".")
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + parts[1] + "."
+
+	id, err := j.Verify(valid)
+	if err != nil || id.Subject != "alice" || !id.HasRole("admin") || id.Method != "jwt" {
+		t.Errorf("Verify(valid) = %+v, %v", id, err)
+	}
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"expired", sign(Claims{Subject: "alice", ExpiresAt: 1000}), ErrExpired},
+		{"not yet valid", sign(Claims{Subject: "alice", NotBefore: 1500}), ErrInvalidToken},
+		{"no subject", sign(Claims{}), ErrInvalidToken},
+		{"other key", forged, ErrInvalidToken},
+		{"alg none", unsigned, ErrInvalidToken},
+		{"tampered claims", parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"mallory","roles":["admin"]}`)) + "." + parts[2], ErrInvalidToken},
+		{"garbage", "a.b", ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		if _, err := j.Verify(tt.token); !errors.Is(err, tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	os.WriteFile(path, []byte("keys:\n  - {key: k1, subject: bob, roles: [customer]}\n"), 0600)
+	keys, err := LoadAPIKeys(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := keys.Verify("k1"); err != nil || id.Subject != "bob" || id.Method != "api-key" {
+		t.Errorf("Verify(k1) = %+v, %v", id, err)
+	}
+	if _, err := keys.Verify("k2"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify(k2) = %v, want ErrInvalidToken", err)
+	}
+
+	os.WriteFile(path, []byte("keys:\n  - {key: k1, subject: bob}\n  - {key: k1, subject: eve}\n"), 0600)
+	if _, err := LoadAPIKeys(path); err == nil {
+		t.Error("duplicate keys were accepted")
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	os.WriteFile(path, []byte("keys:\n  - {key: admin-key, subject: root, roles: [admin]}\n  - {key: user-key, subject: bob}\n"), 0600)
+	keys, err := LoadAPIKeys(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j := NewJWT(testKey)
+	jwtAdmin, _ := j.Sign(Claims{Subject: "carol", Roles: []string{"admin"}})
+	a := &Authenticator{Keys: keys, JWT: j, Policy: Policy{
+		Public: []string{"/svc.Health/"},
+		Roles:  map[string][]string{"/svc.Admin/": {"admin"}, "/svc.Admin/List": nil},
+	}}
+
+	tests := []struct {
+		method, authorization string
+		code                  codes.Code
+		subject               string
+	}{
+		{"/svc.Orders/Place", "", codes.Unauthenticated, ""},
+		{"/svc.Orders/Place", "Basic user-key", codes.Unauthenticated, ""},
+		{"/svc.Orders/Place", "Bearer nope", codes.Unauthenticated, ""},
+		{"/svc.Orders/Place", "Bearer user-key", codes.OK, "bob"},
+		{"/svc.Orders/Place", "bearer " + jwtAdmin, codes.OK, "carol"},
+		{"/svc.Admin/Add", "Bearer user-key", codes.PermissionDenied, ""},
+		{"/svc.Admin/Add", "Bearer admin-key", codes.OK, "root"},
+		{"/svc.Admin/Add", "Bearer " + jwtAdmin, codes.OK, "carol"},
+		{"/svc.Admin/List", "Bearer user-key", codes.OK, "bob"},
+		{"/svc.Health/Check", "", codes.OK, ""},
+	}
+	unary := a.UnaryServerInterceptor()
+	stream := a.StreamServerInterceptor()
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.authorization != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+		}
+
+		var subject string
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			id, _ := FromContext(ctx)
+			subject = id.Subject
+			return nil, nil
+		})
+		if status.Code(err) != tt.code || subject != tt.subject {
+			t.Errorf("unary %v with %q: got %v as %q, want %v as %q", tt.method, tt.authorization, status.Code(err), subject, tt.code, tt.subject)
+		}
+
+		subject = ""
+		err = stream(nil, &fakeStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: tt.method}, func(srv interface{}, ss grpc.ServerStream) error {
+			id, _ := FromContext(ss.Context())
+			subject = id.Subject
+			return nil
+		})
+		if status.Code(err) != tt.code || subject != tt.subject {
+			t.Errorf("stream %v with %q: got %v as %q, want %v as %q", tt.method, tt.authorization, status.Code(err), subject, tt.code, tt.subject)
+		}
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+)
+
+type bearer struct {
+	token      string
+	requireTLS bool
+}
+
+// Bearer sends token as "authorization: Bearer <token>" on every RPC. Only pass
+// requireTLS false for local plaintext setups: the token travels in the clear.
+func Bearer(token string, requireTLS bool) credentials.PerRPCCredentials {
+	return bearer{token: token, requireTLS: requireTLS}
+}
+
+func (b bearer) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+func (b bearer) RequireTransportSecurity() bool {
+	return b.requireTLS
+}
@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Policy says who may call what. Keys are full method names
+// ("/package.Service/Method") or whole services ("/package.Service/"); a
+// method entry wins over its service's.
+type Policy struct {
+	// Public methods may be called without a token
+	Public []string
+	// Roles lists the roles of which the caller needs at least one; methods
+	// without an entry only need an authenticated caller
+	Roles map[string][]string
+}
+
+func (p Policy) public(method string) bool {
+	for _, m := range p.Public {
+		if m == method || m == service(method) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Policy) roles(method string) []string {
+	if roles, ok := p.Roles[method]; ok {
+		return roles
+	}
+	return p.Roles[service(method)]
+}
+
+// service turns "/pkg.Service/Method" into "/pkg.Service/"
+func service(method string) string {
+	return method[:strings.LastIndex(method, "/")+1]
+}
+
+// Authenticator verifies bearer tokens with whichever of its verifiers is set:
+// tokens shaped like a JWT go to JWT, anything else to Keys
+type Authenticator struct {
+	Keys   *APIKeys
+	JWT    *JWT
+	Policy Policy
+}
+
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &identityStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize returns ctx carrying the caller's identity, or the status error
+// to fail the call with
+func (a *Authenticator) authorize(ctx context.Context, method string) (context.Context, error) {
+	if a.Policy.public(method) {
+		return ctx, nil
+	}
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	id, err := a.Verify(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	roles := a.Policy.roles(method)
+	if len(roles) == 0 {
+		return NewContext(ctx, id), nil
+	}
+	for _, role := range roles {
+		if id.HasRole(role) {
+			return NewContext(ctx, id), nil
+		}
+	}
+	return nil, status.Errorf(codes.PermissionDenied, "%v needs one of the roles %v", method, strings.Join(roles, ", "))
+}
+
+func (a *Authenticator) Verify(token string) (Identity, error) {
+	if strings.Count(token, ".") == 2 && a.JWT != nil {
+		return a.JWT.Verify(token)
+	}
+	if a.Keys != nil {
+		return a.Keys.Verify(token)
+	}
+	return Identity{}, ErrInvalidToken
+}
+
+func bearerToken(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", ErrNoToken
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || strings.TrimSpace(token) == "" {
+		return "", fmt.Errorf("%w: authorization must be \"Bearer <token>\"", ErrInvalidToken)
+	}
+	return strings.TrimSpace(token), nil
+}
+
+// identityStream hands the authorized context to stream handlers
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,187 @@
+// Package catalog holds the items the order server can sell, behind a common
+// interface so the backing store can be chosen at startup.
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrNotFound = errors.New("catalog: item not found")
+	ErrExists   = errors.New("catalog: item already exists")
+	ErrInvalid  = errors.New("catalog: invalid item")
+
+	ErrInsufficientStock = errors.New("catalog: insufficient stock")
+	ErrClosed            = errors.New("catalog: closed")
+)
+
+type Item struct {
+	ID       string `json:"id" yaml:"id"`
+	Name     string `json:"name" yaml:"name"`
+	Quantity int32  `json:"quantity" yaml:"quantity"`
+}
+
+// Line is a quantity of one item, as reserved together with others
+type Line struct {
+	ID       string
+	Quantity int32
+}
+
+type Catalog interface {
+	// Items returns a snapshot of the catalog in display order
+	Items() ([]Item, error)
+	// Get returns the item with the given ID or ErrNotFound
+	Get(id string) (Item, error)
+
+	// Add appends a new item, failing with ErrExists if the ID is taken
+	Add(item Item) error
+	// Update applies change to the item with the given ID in one atomic step,
+	// so stock reserved meanwhile is never written back over; it fails with
+	// ErrNotFound if there is no such item, or with the error change returns
+	Update(id string, change func(*Item) error) (Item, error)
+	// Remove deletes the item with the given ID or fails with ErrNotFound
+	Remove(id string) error
+
+	// Reserve atomically takes quantity units out of stock. If fewer are
+	// available it fails with ErrInsufficientStock and changes nothing; the
+	// returned item shows the stock left either way.
+	Reserve(id string, quantity int32) (Item, error)
+	// ReserveAll reserves every line or none: if an item is missing or short
+	// it fails like Reserve, returning that item, and leaves the stock as it was
+	ReserveAll(lines []Line) (Item, error)
+	// Restock atomically puts quantity units back into stock
+	Restock(id string, quantity int32) (Item, error)
+
+	// Subscribe returns a channel of changes made after the call; the channel
+	// is closed when cancel is called, the catalog closes or the subscriber
+	// falls too far behind
+	Subscribe() (events <-chan Event, cancel func())
+	// Ready returns nil while the backend can serve requests, or why it cannot
+	Ready() error
+	Close() error
+}
+
+// DefaultItems is the catalog the server shipped with before backends were pluggable
+func DefaultItems() []Item {
+	return []Item{
+		{ID: "item-1", Name: "banana", Quantity: 120},
+		{ID: "item-2", Name: "apple", Quantity: 200},
+		{ID: "item-3", Name: "orange", Quantity: 150},
+		{ID: "item-4", Name: "grape", Quantity: 80},
+		{ID: "item-5", Name: "red apple", Quantity: 90},
+		{ID: "item-6", Name: "kiwi", Quantity: 60},
+		{ID: "item-7", Name: "mango", Quantity: 40},
+		{ID: "item-8", Name: "pear", Quantity: 70},
+		{ID: "item-9", Name: "cherry", Quantity: 300},
+		{ID: "item-10", Name: "green apple", Quantity: 90},
+	}
+}
+
+// Open creates the catalog backend selected by kind ("memory", "file" or "kv");
+// path is the file or database location and is ignored for memory
+func Open(kind, path string) (Catalog, error) {
+	switch kind {
+	case "memory":
+		return NewMemory(DefaultItems()), nil
+	case "file":
+		return NewFile(path)
+	case "kv":
+		return NewKV(path)
+	default:
+		return nil, fmt.Errorf("catalog: unknown backend %q", kind)
+	}
+}
+
+func validate(items []Item) error {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if err := validateItem(item); err != nil {
+			return err
+		}
+		if seen[item.ID] {
+			return fmt.Errorf("%w: duplicate item id %s", ErrInvalid, item.ID)
+		}
+		seen[item.ID] = true
+	}
+	return nil
+}
+
+func validateItem(item Item) error {
+	if item.ID == "" || item.Name == "" {
+		return fmt.Errorf("%w: item needs both an id and a name", ErrInvalid)
+	}
+	if item.Quantity < 0 {
+		return fmt.Errorf("%w: item %s has negative quantity", ErrInvalid, item.ID)
+	}
+	return nil
+}
+
+// changeItem runs change on a copy of item and checks the result, which must
+// keep the item's ID
+func changeItem(item Item, change func(*Item) error) (Item, error) {
+	changed := item
+	if err := change(&changed); err != nil {
+		return item, err
+	}
+	if changed.ID != item.ID {
+		return item, fmt.Errorf("%w: item id %s cannot change", ErrInvalid, item.ID)
+	}
+	if err := validateItem(changed); err != nil {
+		return item, err
+	}
+	return changed, nil
+}
+
+// adjustStock applies a stock change of delta to item, refusing to go below
+// zero or past what an int32 holds
+func adjustStock(item Item, delta int32) (Item, error) {
+	quantity := int64(item.Quantity) + int64(delta)
+	switch {
+	case quantity < 0:
+		return item, fmt.Errorf("%w: %d of %s requested, %d available", ErrInsufficientStock, -delta, item.ID, item.Quantity)
+	case quantity > math.MaxInt32:
+		return item, fmt.Errorf("%w: %d more of %s would exceed %d units", ErrInvalid, delta, item.ID, math.MaxInt32)
+	}
+	item.Quantity = int32(quantity)
+	return item, nil
+}
+
+// reserveLines takes lines out of the stock read by get and hands the changed
+// items to put, but only once every line fits. Lines naming the same item add up.
+func reserveLines(lines []Line, get func(id string) (Item, error), put func(Item) error) (Item, error) {
+	reserved := make(map[string]Item, len(lines))
+	var ids []string
+	for _, line := range lines {
+		if err := checkQuantity(line.Quantity); err != nil {
+			return Item{ID: line.ID}, err
+		}
+		item, ok := reserved[line.ID]
+		if !ok {
+			var err error
+			if item, err = get(line.ID); err != nil {
+				return Item{ID: line.ID}, err
+			}
+			ids = append(ids, line.ID)
+		}
+		item, err := adjustStock(item, -line.Quantity)
+		if err != nil {
+			return item, err
+		}
+		reserved[line.ID] = item
+	}
+	for _, id := range ids {
+		if err := put(reserved[id]); err != nil {
+			return reserved[id], err
+		}
+	}
+	return Item{}, nil
+}
+
+func checkQuantity(quantity int32) error {
+	if quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be positive, got %d", ErrInvalid, quantity)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package catalog
+
+import "sync"
+
+type EventType int
+
+const (
+	ItemAdded EventType = iota + 1
+	ItemUpdated
+	ItemRemoved
+)
+
+func (t EventType) String() string {
+	switch t {
+	case ItemAdded:
+		return "added"
+	case ItemUpdated:
+		return "updated"
+	case ItemRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes one change; for ItemRemoved, Item holds the removed item
+type Event struct {
+	Type EventType
+	Item Item
+}
+
+// subscriberBuffer is how many events a subscriber may lag behind before it is dropped
+const subscriberBuffer = 256
+
+// notifier fans catalog changes out to subscribers; backends embed it
+type notifier struct {
+	mtx    sync.Mutex
+	subs   map[chan Event]struct{}
+	closed bool
+}
+
+func (n *notifier) Subscribe() (<-chan Event, func()) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	ch := make(chan Event, subscriberBuffer)
+	if n.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if n.subs == nil {
+		n.subs = make(map[chan Event]struct{})
+	}
+	n.subs[ch] = struct{}{}
+	return ch, func() { n.unsubscribe(ch) }
+}
+
+func (n *notifier) unsubscribe(ch chan Event) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if _, ok := n.subs[ch]; ok {
+		delete(n.subs, ch)
+		close(ch)
+	}
+}
+
+// notify never blocks: a subscriber whose buffer is full is dropped so one
+// slow watcher cannot stall catalog writes
+func (n *notifier) notify(events ...Event) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	for ch := range n.subs {
+		if !deliver(ch, events) {
+			delete(n.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+func deliver(ch chan Event, events []Event) bool {
+	for _, event := range events {
+		select {
+		case ch <- event:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (n *notifier) isClosed() bool {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return n.closed
+}
+
+func (n *notifier) closeAll() {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.closed = true
+	for ch := range n.subs {
+		delete(n.subs, ch)
+		close(ch)
+	}
+}
+
+// diff lists the events that turn old into new, matching items by ID
+func diff(old, new []Item) []Event {
+	before := make(map[string]Item, len(old))
+	for _, item := range old {
+		before[item.ID] = item
+	}
+
+	var events []Event
+	for _, item := range new {
+		prev, ok := before[item.ID]
+		switch {
+		case !ok:
+			events = append(events, Event{Type: ItemAdded, Item: item})
+		case prev != item:
+			events = append(events, Event{Type: ItemUpdated, Item: item})
+		}
+		delete(before, item.ID)
+	}
+	for _, item := range old {
+		if _, removed := before[item.ID]; removed {
+			events = append(events, Event{Type: ItemRemoved, Item: item})
+		}
+	}
+	return events
+}
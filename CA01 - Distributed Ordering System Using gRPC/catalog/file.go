@@ -0,0 +1,305 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/m-hariri/basic-go-grpc/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// flushInterval is how often changes made through a File are saved at most
+const flushInterval = time.Second
+
+// File serves a catalog loaded from a JSON or YAML file and reloads it
+// whenever the file changes on disk. Changes made through the catalog apply
+// in memory and are saved back to the file in batches, at most once per
+// flushInterval and when the catalog closes, so orders never wait on file I/O.
+// The file is meant to be read mostly: edit stock through the admin service
+// while the server runs, as an edit saved over a newer file replaces the
+// quantities it changes.
+type File struct {
+	*Memory
+	path     string
+	writeMtx sync.Mutex // serializes writes to the file with reloads from it
+	saved    []Item     // the items last read from or written to the file, under writeMtx
+	dirty    atomic.Bool
+	watcher  *fsnotify.Watcher
+	done     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+	flushed  chan struct{}
+
+	errMtx    sync.Mutex
+	reloadErr error // why the last reload failed, nil once one succeeds
+	saveErr   error // why the last save failed, nil once one succeeds
+}
+
+// the on-disk layout shared by the JSON and YAML formats
+type catalogFile struct {
+	Items []Item `json:"items" yaml:"items"`
+}
+
+func NewFile(path string) (*File, error) {
+	if path == "" {
+		return nil, fmt.Errorf("catalog: file backend needs a path")
+	}
+	items, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// watch the directory rather than the file so editors that save by
+	// renaming a temp file over it still trigger a reload
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	f := &File{
+		Memory:  NewMemory(items),
+		path:    path,
+		saved:   items,
+		watcher: watcher,
+		done:    make(chan struct{}),
+		stop:    make(chan struct{}),
+		flushed: make(chan struct{}),
+	}
+	go f.watch()
+	go f.flush()
+	return f, nil
+}
+
+func (f *File) Add(item Item) error {
+	return f.changed(f.Memory.Add(item))
+}
+
+func (f *File) Update(id string, change func(*Item) error) (Item, error) {
+	item, err := f.Memory.Update(id, change)
+	return item, f.changed(err)
+}
+
+func (f *File) Remove(id string) error {
+	return f.changed(f.Memory.Remove(id))
+}
+
+func (f *File) Reserve(id string, quantity int32) (Item, error) {
+	item, err := f.Memory.Reserve(id, quantity)
+	return item, f.changed(err)
+}
+
+func (f *File) ReserveAll(lines []Line) (Item, error) {
+	short, err := f.Memory.ReserveAll(lines)
+	return short, f.changed(err)
+}
+
+func (f *File) Restock(id string, quantity int32) (Item, error) {
+	item, err := f.Memory.Restock(id, quantity)
+	return item, f.changed(err)
+}
+
+// changed marks the items for the next save unless the change failed
+func (f *File) changed(err error) error {
+	if err == nil {
+		f.dirty.Store(true)
+	}
+	return err
+}
+
+// Ready fails while the file on disk cannot be loaded or saved: the catalog
+// then serves stale items and would lose edits made to the file, or its own
+func (f *File) Ready() error {
+	if err := f.Memory.Ready(); err != nil {
+		return err
+	}
+	f.errMtx.Lock()
+	defer f.errMtx.Unlock()
+	if f.reloadErr != nil {
+		return fmt.Errorf("catalog: reload %v: %w", f.path, f.reloadErr)
+	}
+	if f.saveErr != nil {
+		return fmt.Errorf("catalog: save %v: %w", f.path, f.saveErr)
+	}
+	return nil
+}
+
+// Close saves the changes not saved yet
+func (f *File) Close() error {
+	f.stopOnce.Do(func() { close(f.stop) })
+	<-f.flushed
+	err := f.watcher.Close()
+	<-f.done
+	f.Memory.Close()
+	if f.dirty.Load() {
+		if err := f.save(); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// flush saves the changes made since the last save every flushInterval; a
+// failed save is tried again on the next tick
+func (f *File) flush() {
+	defer close(f.flushed)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.stop:
+			return
+		case <-ticker.C:
+			if f.dirty.Swap(false) && f.save() != nil {
+				f.dirty.Store(true)
+			}
+		}
+	}
+}
+
+// save writes the items as they are now. The reload triggered by our own
+// write finds nothing new, so subscribers see each change once.
+func (f *File) save() error {
+	f.writeMtx.Lock()
+	defer f.writeMtx.Unlock()
+
+	items, _ := f.Memory.Items()
+	err := writeFile(f.path, items)
+	if err == nil {
+		f.saved = items
+	}
+	f.errMtx.Lock()
+	defer f.errMtx.Unlock()
+	if err != nil && f.saveErr == nil {
+		logging.Errorf("Catalog save failed, trying again: %v", err)
+	}
+	f.saveErr = err
+	return err
+}
+
+func (f *File) watch() {
+	defer close(f.done)
+	name := filepath.Clean(f.path)
+	for {
+		select {
+		case event, ok := <-f.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != name || !event.Has(fsnotify.Write|fsnotify.Create) {
+				continue
+			}
+			err := f.reload()
+			if err != nil {
+				logging.Warnf("Catalog reload failed, keeping previous items: %v", err)
+			}
+			f.errMtx.Lock()
+			f.reloadErr = err
+			f.errMtx.Unlock()
+		case err, ok := <-f.watcher.Errors:
+			if !ok {
+				return
+			}
+			logging.Errorf("Catalog watcher error: %v", err)
+		}
+	}
+}
+
+// reload takes the file's items, but an item whose quantity in the file is
+// the one last saved keeps the stock it has now: the edit was not about its
+// stock, and orders since the last save must not be undone
+func (f *File) reload() error {
+	f.writeMtx.Lock()
+	defer f.writeMtx.Unlock()
+
+	items, err := readFile(f.path)
+	if err != nil {
+		return err
+	}
+	saved := make(map[string]int32, len(f.saved))
+	for _, item := range f.saved {
+		saved[item.ID] = item.Quantity
+	}
+	f.replace(func(current []Item) []Item {
+		live := make(map[string]int32, len(current))
+		for _, item := range current {
+			live[item.ID] = item.Quantity
+		}
+		merged := append([]Item(nil), items...)
+		for i, item := range merged {
+			quantity, ok := live[item.ID]
+			if q, unchanged := saved[item.ID]; ok && unchanged && q == item.Quantity && quantity != item.Quantity {
+				merged[i].Quantity = quantity
+				f.dirty.Store(true)
+			}
+		}
+		return merged
+	})
+	f.saved = items
+	logging.Infof("Catalog reloaded from %v with %d items", f.path, len(items))
+	return nil
+}
+
+func readFile(path string) ([]Item, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed catalogFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &parsed)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &parsed)
+	default:
+		return nil, fmt.Errorf("catalog: unsupported file type %q (use .json, .yaml or .yml)", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("catalog: parse %v: %w", path, err)
+	}
+	if err := validate(parsed.Items); err != nil {
+		return nil, err
+	}
+	return parsed.Items, nil
+}
+
+// writeFile saves items in the format given by the file extension, going
+// through a temporary file so readers never see a half-written catalog
+func writeFile(path string, items []Item) error {
+	var data []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		data, err = json.MarshalIndent(catalogFile{Items: items}, "", "  ")
+	default:
+		data, err = yaml.Marshal(catalogFile{Items: items})
+	}
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
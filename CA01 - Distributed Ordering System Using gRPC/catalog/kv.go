@@ -0,0 +1,271 @@
+package catalog
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	itemsBucket = []byte("items") // insertion sequence -> JSON item, keeps display order
+	idsBucket   = []byte("ids")   // item ID -> insertion sequence
+)
+
+// KV stores the catalog in an embedded bbolt database on disk
+type KV struct {
+	notifier
+	// writeMtx is held from a write transaction until its events are sent:
+	// bbolt commits one write at a time, but notifying after the commit would
+	// let a later write's event overtake an earlier one's
+	writeMtx sync.Mutex
+	db       *bolt.DB
+}
+
+// NewKV opens (or creates) the database at path and seeds it with
+// DefaultItems when it holds no items yet
+func NewKV(path string) (*KV, error) {
+	if path == "" {
+		return nil, fmt.Errorf("catalog: kv backend needs a path")
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("catalog: open %v: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		items, err := tx.CreateBucketIfNotExists(itemsBucket)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucketIfNotExists(idsBucket); err != nil {
+			return err
+		}
+		if items.Stats().KeyN > 0 {
+			return nil
+		}
+		for _, item := range DefaultItems() {
+			if err := putItem(tx, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &KV{db: db}, nil
+}
+
+func (kv *KV) Items() ([]Item, error) {
+	var items []Item
+	err := kv.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(itemsBucket).ForEach(func(_, v []byte) error {
+			var item Item
+			if err := json.Unmarshal(v, &item); err != nil {
+				return err
+			}
+			items = append(items, item)
+			return nil
+		})
+	})
+	return items, err
+}
+
+func (kv *KV) Get(id string) (Item, error) {
+	var item Item
+	err := kv.db.View(func(tx *bolt.Tx) error {
+		seq := tx.Bucket(idsBucket).Get([]byte(id))
+		if seq == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(tx.Bucket(itemsBucket).Get(seq), &item)
+	})
+	return item, err
+}
+
+func (kv *KV) Add(item Item) error {
+	if err := validateItem(item); err != nil {
+		return err
+	}
+	kv.writeMtx.Lock()
+	defer kv.writeMtx.Unlock()
+	err := kv.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(idsBucket).Get([]byte(item.ID)) != nil {
+			return ErrExists
+		}
+		return putItem(tx, item)
+	})
+	if err != nil {
+		return err
+	}
+	kv.notify(Event{Type: ItemAdded, Item: item})
+	return nil
+}
+
+// Update reads and writes the item in one transaction, like adjust
+func (kv *KV) Update(id string, change func(*Item) error) (Item, error) {
+	kv.writeMtx.Lock()
+	defer kv.writeMtx.Unlock()
+	var item Item
+	err := kv.db.Update(func(tx *bolt.Tx) error {
+		seq := tx.Bucket(idsBucket).Get([]byte(id))
+		if seq == nil {
+			return ErrNotFound
+		}
+		if err := json.Unmarshal(tx.Bucket(itemsBucket).Get(seq), &item); err != nil {
+			return err
+		}
+		var err error
+		if item, err = changeItem(item, change); err != nil {
+			return err
+		}
+		return putItem(tx, item)
+	})
+	if err != nil {
+		return item, err
+	}
+	kv.notify(Event{Type: ItemUpdated, Item: item})
+	return item, nil
+}
+
+func (kv *KV) Remove(id string) error {
+	kv.writeMtx.Lock()
+	defer kv.writeMtx.Unlock()
+	var removed Item
+	err := kv.db.Update(func(tx *bolt.Tx) error {
+		items, ids := tx.Bucket(itemsBucket), tx.Bucket(idsBucket)
+		seq := ids.Get([]byte(id))
+		if seq == nil {
+			return ErrNotFound
+		}
+		if err := json.Unmarshal(items.Get(seq), &removed); err != nil {
+			return err
+		}
+		if err := items.Delete(seq); err != nil {
+			return err
+		}
+		return ids.Delete([]byte(id))
+	})
+	if err != nil {
+		return err
+	}
+	kv.notify(Event{Type: ItemRemoved, Item: removed})
+	return nil
+}
+
+func (kv *KV) Reserve(id string, quantity int32) (Item, error) {
+	if err := checkQuantity(quantity); err != nil {
+		return Item{}, err
+	}
+	return kv.adjust(id, -quantity)
+}
+
+// ReserveAll checks and writes every line in one transaction, which bbolt
+// rolls back as a whole when a line does not fit
+func (kv *KV) ReserveAll(lines []Line) (Item, error) {
+	kv.writeMtx.Lock()
+	defer kv.writeMtx.Unlock()
+	var short Item
+	var updated []Item
+	err := kv.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		short, err = reserveLines(lines, func(id string) (Item, error) {
+			var item Item
+			seq := tx.Bucket(idsBucket).Get([]byte(id))
+			if seq == nil {
+				return item, ErrNotFound
+			}
+			err := json.Unmarshal(tx.Bucket(itemsBucket).Get(seq), &item)
+			return item, err
+		}, func(item Item) error {
+			updated = append(updated, item)
+			return putItem(tx, item)
+		})
+		return err
+	})
+	if err != nil {
+		return short, err
+	}
+	for _, item := range updated {
+		kv.notify(Event{Type: ItemUpdated, Item: item})
+	}
+	return Item{}, nil
+}
+
+func (kv *KV) Restock(id string, quantity int32) (Item, error) {
+	if err := checkQuantity(quantity); err != nil {
+		return Item{}, err
+	}
+	return kv.adjust(id, quantity)
+}
+
+// adjust relies on bbolt running one write transaction at a time, which makes
+// the read-check-write of the stock atomic
+func (kv *KV) adjust(id string, delta int32) (Item, error) {
+	kv.writeMtx.Lock()
+	defer kv.writeMtx.Unlock()
+	var item Item
+	err := kv.db.Update(func(tx *bolt.Tx) error {
+		seq := tx.Bucket(idsBucket).Get([]byte(id))
+		if seq == nil {
+			return ErrNotFound
+		}
+		if err := json.Unmarshal(tx.Bucket(itemsBucket).Get(seq), &item); err != nil {
+			return err
+		}
+		var err error
+		if item, err = adjustStock(item, delta); err != nil {
+			return err
+		}
+		return putItem(tx, item)
+	})
+	if err != nil {
+		return item, err
+	}
+	kv.notify(Event{Type: ItemUpdated, Item: item})
+	return item, nil
+}
+
+func (kv *KV) Ready() error {
+	return kv.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(itemsBucket) == nil || tx.Bucket(idsBucket) == nil {
+			return fmt.Errorf("catalog: database has no item buckets")
+		}
+		return nil
+	})
+}
+
+func (kv *KV) Close() error {
+	kv.closeAll()
+	return kv.db.Close()
+}
+
+// putItem appends a new item or overwrites an existing one in place
+func putItem(tx *bolt.Tx, item Item) error {
+	items, ids := tx.Bucket(itemsBucket), tx.Bucket(idsBucket)
+	value, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	var key []byte
+	if existing := ids.Get([]byte(item.ID)); existing != nil {
+		key = append(key, existing...)
+	} else {
+		seq, err := items.NextSequence()
+		if err != nil {
+			return err
+		}
+		key = make([]byte, 8)
+		binary.BigEndian.PutUint64(key, seq)
+		if err := ids.Put([]byte(item.ID), key); err != nil {
+			return err
+		}
+	}
+	return items.Put(key, value)
+}
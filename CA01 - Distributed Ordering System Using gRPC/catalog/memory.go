@@ -0,0 +1,159 @@
+package catalog
+
+import "sync"
+
+// Memory keeps the catalog in a slice guarded by a lock; the file backend
+// embeds it and swaps the contents on reload
+type Memory struct {
+	notifier
+	mtx   sync.RWMutex
+	items []Item
+}
+
+func NewMemory(items []Item) *Memory {
+	return &Memory{items: append([]Item(nil), items...)}
+}
+
+func (m *Memory) Items() ([]Item, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return append([]Item(nil), m.items...), nil
+}
+
+func (m *Memory) Get(id string) (Item, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	if i := m.indexOf(id); i >= 0 {
+		return m.items[i], nil
+	}
+	return Item{}, ErrNotFound
+}
+
+func (m *Memory) Add(item Item) error {
+	if err := validateItem(item); err != nil {
+		return err
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.indexOf(item.ID) >= 0 {
+		return ErrExists
+	}
+	m.items = append(m.items, item)
+	m.notify(Event{Type: ItemAdded, Item: item})
+	return nil
+}
+
+func (m *Memory) Update(id string, change func(*Item) error) (Item, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	i := m.indexOf(id)
+	if i < 0 {
+		return Item{}, ErrNotFound
+	}
+	item, err := changeItem(m.items[i], change)
+	if err != nil {
+		return item, err
+	}
+	m.items[i] = item
+	m.notify(Event{Type: ItemUpdated, Item: item})
+	return item, nil
+}
+
+func (m *Memory) Remove(id string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	i := m.indexOf(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	removed := m.items[i]
+	m.items = append(m.items[:i:i], m.items[i+1:]...)
+	m.notify(Event{Type: ItemRemoved, Item: removed})
+	return nil
+}
+
+func (m *Memory) Reserve(id string, quantity int32) (Item, error) {
+	if err := checkQuantity(quantity); err != nil {
+		return Item{}, err
+	}
+	return m.adjust(id, -quantity)
+}
+
+func (m *Memory) Restock(id string, quantity int32) (Item, error) {
+	if err := checkQuantity(quantity); err != nil {
+		return Item{}, err
+	}
+	return m.adjust(id, quantity)
+}
+
+func (m *Memory) ReserveAll(lines []Line) (Item, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	var updated []Item
+	short, err := reserveLines(lines, func(id string) (Item, error) {
+		if i := m.indexOf(id); i >= 0 {
+			return m.items[i], nil
+		}
+		return Item{}, ErrNotFound
+	}, func(item Item) error {
+		m.items[m.indexOf(item.ID)] = item
+		updated = append(updated, item)
+		return nil
+	})
+	if err != nil {
+		return short, err
+	}
+	for _, item := range updated {
+		m.notify(Event{Type: ItemUpdated, Item: item})
+	}
+	return Item{}, nil
+}
+
+func (m *Memory) adjust(id string, delta int32) (Item, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	i := m.indexOf(id)
+	if i < 0 {
+		return Item{}, ErrNotFound
+	}
+	item, err := adjustStock(m.items[i], delta)
+	if err != nil {
+		return item, err
+	}
+	m.items[i] = item
+	m.notify(Event{Type: ItemUpdated, Item: item})
+	return item, nil
+}
+
+func (m *Memory) Ready() error {
+	if m.isClosed() {
+		return ErrClosed
+	}
+	return nil
+}
+
+func (m *Memory) Close() error {
+	m.closeAll()
+	return nil
+}
+
+// replace swaps in the item list update makes of the current one and
+// notifies subscribers of the difference
+func (m *Memory) replace(update func(current []Item) []Item) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	items := update(append([]Item(nil), m.items...))
+	events := diff(m.items, items)
+	m.items = append([]Item(nil), items...)
+	m.notify(events...)
+}
+
+// indexOf must be called with m.mtx held
+func (m *Memory) indexOf(id string) int {
+	for i, item := range m.items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
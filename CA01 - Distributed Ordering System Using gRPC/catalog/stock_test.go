@@ -0,0 +1,295 @@
+package catalog
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func openBackends(t *testing.T) map[string]Catalog {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "catalog.json")
+	if err := writeFile(path, DefaultItems()); err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kv, err := NewKV(filepath.Join(dir, "catalog.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	backends := map[string]Catalog{"memory": NewMemory(DefaultItems()), "file": file, "kv": kv}
+	t.Cleanup(func() {
+		for _, c := range backends {
+			c.Close()
+		}
+	})
+	return backends
+}
+
+// Run with -race: every backend must hand out exactly the stock it has, however
+// many callers reserve at once
+func TestReserveNeverOversells(t *testing.T) {
+	const workers, attempts = 40, 10
+	for name, c := range openBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			apple, err := c.Get("item-2")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var reserved int32
+			var wg sync.WaitGroup
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < attempts; i++ {
+						item, err := c.Reserve("item-2", 1)
+						switch {
+						case err == nil:
+							atomic.AddInt32(&reserved, 1)
+						case errors.Is(err, ErrInsufficientStock):
+							if item.Quantity != 0 {
+								t.Errorf("rejected with %d still in stock", item.Quantity)
+							}
+						default:
+							t.Error(err)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			if reserved != apple.Quantity {
+				t.Errorf("reserved %d units out of %d in stock", reserved, apple.Quantity)
+			}
+			if item, _ := c.Get("item-2"); item.Quantity != 0 {
+				t.Errorf("%d left after selling out", item.Quantity)
+			}
+		})
+	}
+}
+
+func TestRestock(t *testing.T) {
+	for name, c := range openBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			if _, err := c.Reserve("item-7", 41); !errors.Is(err, ErrInsufficientStock) {
+				t.Fatalf("reserving 41 of 40 mango: got %v, want ErrInsufficientStock", err)
+			}
+			item, err := c.Restock("item-7", 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if item.Quantity != 50 {
+				t.Errorf("quantity after restock = %d, want 50", item.Quantity)
+			}
+			if item, err = c.Reserve("item-7", 41); err != nil || item.Quantity != 9 {
+				t.Errorf("reserving 41 after restock = %d, %v; want 9 left", item.Quantity, err)
+			}
+			if _, err := c.Restock("item-7", 0); !errors.Is(err, ErrInvalid) {
+				t.Errorf("restocking 0: got %v, want ErrInvalid", err)
+			}
+			if _, err := c.Restock("item-7", math.MaxInt32); !errors.Is(err, ErrInvalid) {
+				t.Errorf("restocking past int32: got %v, want ErrInvalid", err)
+			}
+			if item, _ := c.Get("item-7"); item.Quantity != 9 {
+				t.Errorf("quantity after a refused restock = %d, want 9", item.Quantity)
+			}
+			if _, err := c.Reserve("no-such-item", 1); !errors.Is(err, ErrNotFound) {
+				t.Errorf("reserving unknown item: got %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestReserveAllIsAllOrNothing(t *testing.T) {
+	for name, c := range openBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			// 40 mango in stock, asked for in two lines adding up to 41
+			short, err := c.ReserveAll([]Line{{"item-1", 5}, {"item-7", 30}, {"item-7", 11}})
+			if !errors.Is(err, ErrInsufficientStock) || short.ID != "item-7" {
+				t.Fatalf("reserving too much mango: got %v, %v; want ErrInsufficientStock for item-7", short, err)
+			}
+			if short, err := c.ReserveAll([]Line{{"item-1", 5}, {"no-such-item", 1}}); !errors.Is(err, ErrNotFound) || short.ID != "no-such-item" {
+				t.Errorf("reserving unknown item: got %v, %v; want ErrNotFound", short, err)
+			}
+			if banana, _ := c.Get("item-1"); banana.Quantity != 120 {
+				t.Errorf("failed reservations left %d banana, want 120", banana.Quantity)
+			}
+
+			if _, err := c.ReserveAll([]Line{{"item-1", 5}, {"item-7", 40}}); err != nil {
+				t.Fatal(err)
+			}
+			banana, _ := c.Get("item-1")
+			mango, _ := c.Get("item-7")
+			if banana.Quantity != 115 || mango.Quantity != 0 {
+				t.Errorf("left %d banana and %d mango, want 115 and 0", banana.Quantity, mango.Quantity)
+			}
+		})
+	}
+}
+
+// Run with -race: a rename applies to the item as it is, so the stock reserved
+// while it runs is never written back over
+func TestRenameKeepsReservations(t *testing.T) {
+	const workers, attempts = 20, 5
+	for name, c := range openBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			for w := 0; w < workers; w++ {
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < attempts; i++ {
+						if _, err := c.Reserve("item-2", 1); err != nil {
+							t.Error(err)
+						}
+					}
+				}()
+				go func() {
+					defer wg.Done()
+					for i := 0; i < attempts; i++ {
+						if _, err := c.Update("item-2", func(item *Item) error {
+							item.Name = "apple"
+							return nil
+						}); err != nil {
+							t.Error(err)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			// 200 apples in stock
+			if apple, _ := c.Get("item-2"); apple.Quantity != 200-workers*attempts {
+				t.Errorf("%d apples left after reserving %d, want %d", apple.Quantity, workers*attempts, 200-workers*attempts)
+			}
+		})
+	}
+}
+
+// Run with -race: events reach subscribers in the order the writes applied,
+// so a subscriber keeping the last event per item ends up with the stored item
+func TestEventsFollowWriteOrder(t *testing.T) {
+	const workers, restocks = 8, 25 // within a subscriber's buffer
+	for name, c := range openBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			events, cancel := c.Subscribe()
+			defer cancel()
+			var wg sync.WaitGroup
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < restocks; i++ {
+						if _, err := c.Restock("item-7", 1); err != nil {
+							t.Error(err)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			// 40 mango in stock, each restock adds one
+			for want := int32(41); want <= 40+workers*restocks; want++ {
+				if event := <-events; event.Item.Quantity != want {
+					t.Fatalf("event with %d mango, want %d", event.Item.Quantity, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateRefusesInvalidItems(t *testing.T) {
+	for name, c := range openBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			for what, change := range map[string]func(*Item) error{
+				"new id":            func(item *Item) error { item.ID = "item-99"; return nil },
+				"empty name":        func(item *Item) error { item.Name = ""; return nil },
+				"negative quantity": func(item *Item) error { item.Quantity = -1; return nil },
+			} {
+				if _, err := c.Update("item-1", change); !errors.Is(err, ErrInvalid) {
+					t.Errorf("%v: got %v, want ErrInvalid", what, err)
+				}
+			}
+			if banana, _ := c.Get("item-1"); banana != DefaultItems()[0] {
+				t.Errorf("refused updates left %+v", banana)
+			}
+			if _, err := c.Update("no-such-item", func(*Item) error { return nil }); !errors.Is(err, ErrNotFound) {
+				t.Errorf("updating unknown item: got %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestFileStockPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.yaml")
+	if err := os.WriteFile(path, []byte("items:\n  - {id: item-1, name: banana, quantity: 5}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Reserve("item-1", 3); err != nil {
+		t.Fatal(err)
+	}
+	// orders do not wait for the file to be written
+	if items, _ := readFile(path); items[0].Quantity != 5 {
+		t.Errorf("stored quantity = %d right after the order, want 5 until the next save", items[0].Quantity)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	items, err := readFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items[0].Quantity != 2 {
+		t.Errorf("stored quantity = %d, want 2", items[0].Quantity)
+	}
+}
+
+func TestFileReloadKeepsLiveStock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.yaml")
+	if err := os.WriteFile(path, []byte("items:\n  - {id: item-1, name: banana, quantity: 5}\n  - {id: item-2, name: apple, quantity: 5}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.ReserveAll([]Line{{"item-1", 1}, {"item-2", 1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a hand edit renaming the banana and restocking the apple, made before
+	// the orders were saved
+	if err := os.WriteFile(path, []byte("items:\n  - {id: item-1, name: plantain, quantity: 5}\n  - {id: item-2, name: apple, quantity: 9}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if item, _ := f.Get("item-1"); item.Name == "plantain" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the edit was never reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	banana, _ := f.Get("item-1")
+	apple, _ := f.Get("item-2")
+	if banana.Quantity != 4 || apple.Quantity != 9 {
+		t.Errorf("%d plantain and %d apples after the edit, want 4 kept and 9 from the file", banana.Quantity, apple.Quantity)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/status"
+)
+
+// callListItems prints the whole catalog and returns the item names, nil if
+// it could not be listed
+func callListItems(client pb.CatalogAdminServiceClient) []string {
+	items, err := catalogItems(client)
+	if err != nil {
+		log.Printf("Could not list catalog")
+		printStatus(status.Convert(err))
+		return nil
+	}
+	names := make([]string, len(items))
+	for i, item := range items {
+		log.Printf("%v: %v (%d available)", item.Id, item.Name, item.Quantity)
+		names[i] = item.Name
+	}
+	return names
+}
+
+// catalogItems fetches the whole catalog, following page tokens until the
+// last page. Each page gets its own timeout, however many a catalog takes.
+func catalogItems(client pb.CatalogAdminServiceClient) (items []*pb.CatalogItem, err error) {
+	ctx, done := startOperation("list catalog", 0)
+	defer func() { done(err) }()
+
+	req := &pb.ListItemsRequest{}
+	for {
+		pageCtx, cancel := context.WithTimeout(ctx, callTimeout)
+		res, err := client.ListItems(pageCtx, req)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, res.Items...)
+		if res.NextPageToken == "" {
+			return items, nil
+		}
+		req.PageToken = res.NextPageToken
+	}
+}
+
+func callRestockItem(client pb.CatalogAdminServiceClient, id string, quantity int32) {
+	ctx, done := startOperation("restock item", callTimeout)
+	item, err := client.RestockItem(ctx, &pb.RestockItemRequest{Id: id, Quantity: quantity})
+	done(err)
+	if err != nil {
+		logging.For(ctx).Infof("Could not restock item")
+		printStatus(status.Convert(err))
+		return
+	}
+	log.Printf("%v: %v (%d available)", item.Id, item.Name, item.Quantity)
+}
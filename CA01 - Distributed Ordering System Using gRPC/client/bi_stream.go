@@ -1,47 +1,30 @@
 package main
 
 import (
-	"context"
-	"io"
 	"log"
-	"time"
+	"strings"
 
+	"github.com/m-hariri/basic-go-grpc/logging"
 	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/status"
 )
 
 func callGetOrderBidirectionalStream(client pb.OrderServiceClient, orders *pb.NamesList) {
-	log.Printf("Bidirectional Streaming started")
-	stream, err := client.GetOrderBidirectionalStreaming(context.Background())
+	ctx, done := startOperation("stream", streamTimeout)
+	logging.For(ctx).Debugf("Bidirectional Streaming started")
+	retried, err := runBidiStream(ctx, client, orders.Names, sendInterval, printResponse)
+	done(err)
+	reportRetried(retried)
 	if err != nil {
-		log.Fatalf("Could not send orders: %v", err)
+		logging.For(ctx).Infof("Error while streaming")
+		printStatus(status.Convert(err))
+		return
 	}
-	
-	waitc := make(chan struct{}) 
-	go func() {
-		for {
-			message, err := stream.Recv()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				log.Fatalf("Error while streaming %v", err)
-			}
-			log.Println(message)
-		}
-		close(waitc)
-	}()
+	logging.For(ctx).Debugf("Bidirectional Streaming finished")
+}
 
-	for _, name := range orders.Names {
-		req := &pb.OrderRequest{  
-			Name: name,
-		}
-		if err := stream.Send(req); err != nil {
-			log.Fatalf("Error while sending %v", err)
-		}
-		time.Sleep(2 * time.Second)
+func reportRetried(names []string) {
+	if len(names) > 0 {
+		log.Printf("Names sent again after reconnecting: %v", strings.Join(names, ", "))
 	}
-
-	stream.CloseSend()
-	<-waitc
-	log.Printf("Bidirectional Streaming finished")
 }
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"github.com/m-hariri/basic-go-grpc/tracing"
+	"google.golang.org/grpc/status"
+)
+
+const commandHelp = `usage: client [flags] command [arguments]
+
+commands:
+  search [name ...]         look names up on a server streaming call
+  stream [name ...]         look names up on a bidirectional stream (see -send-interval)
+  order [item [quantity]]   order quantity (default 1) of the item with exactly this name;
+                            without arguments, upload the CSV rows item,quantity[,reference]
+                            read from -input or stdin as one bulk order
+  cancel [order-id ...]     cancel orders
+  fulfill [order-id ...]    mark confirmed orders as shipped (admin role)
+  catalog                   list the catalog
+
+Commands given no names or ids read them from -input or stdin, one per line;
+empty lines and lines starting with # are skipped. Flags may follow the command.
+Results go to stdout in the -output format (text, table or json, one object per
+line), problems to stderr. The exit status is 1 if a call or any order,
+cancellation or fulfilment failed, 2 for usage errors.
+
+Without a command the client runs interactively, with history in -history and
+TAB completion of commands and item names; type help there for its commands.
+A quantity follows an x there, so names may end in numbers (order iphone 15 x 2).
+
+Settings come from defaults, then the -config file (or ORDER_CONFIG), then
+ORDER_* environment variables, then flags; -print-config shows the result.
+Fetching an order or a catalog page is retried on UNAVAILABLE up to -retries
+times and broken streams resume; orders, cancellations and fulfilments are not.
+Each command is one trace, its ID sent in the traceparent metadata; -trace-file
+appends the spans as OTLP JSON lines.
+`
+
+var errUsage = errors.New("usage error")
+
+// errPartial reports that some orders, cancellations or fulfilments failed; each failure
+// was printed already
+var errPartial = errors.New("some requests failed")
+
+// cli runs one command against the server instead of the interactive client
+type cli struct {
+	client pb.OrderServiceClient
+	admin  pb.CatalogAdminServiceClient
+	out    *output
+	input  string // file read for arguments, "" or "-" for stdin
+	stdin  io.Reader
+	stderr io.Writer
+}
+
+// commands run as part of the trace of the whole command, each call bounded by
+// its own deadline
+var commands = map[string]func(*cli, context.Context, []string) error{
+	"search":  (*cli).search,
+	"stream":  (*cli).stream,
+	"order":   (*cli).order,
+	"cancel":  (*cli).cancel,
+	"fulfill": (*cli).fulfill,
+	"catalog": (*cli).catalog,
+}
+
+// run executes args[0] with the rest of args and returns the exit status
+func (c *cli) run(args []string) int {
+	if args[0] == "help" {
+		fmt.Fprint(c.out.w, commandHelp)
+		return 0
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(c.stderr, "unknown command %q\n\n%v", args[0], commandHelp)
+		return 2
+	}
+	ctx, span := tracing.Start(context.Background(), "client "+args[0], tracing.Internal)
+	err := command(c, ctx, args[1:])
+	span.End(err)
+	if flushErr := c.out.flush(); err == nil {
+		err = flushErr
+	}
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(c.stderr, "%v\n\n%v", err, commandHelp)
+		return 2
+	case errors.Is(err, errPartial):
+		return 1
+	default:
+		c.fail("", err)
+		return 1
+	}
+}
+
+// fail prints a failed call to stderr, with what it was about if known
+func (c *cli) fail(subject string, err error) {
+	if subject != "" {
+		subject += ": "
+	}
+	if st, ok := status.FromError(err); ok {
+		fmt.Fprintf(c.stderr, "%v%v\n", subject, statusText(st))
+		return
+	}
+	fmt.Fprintf(c.stderr, "%v%v\n", subject, err)
+}
+
+// lines returns args, or if there are none the lines of the input
+func (c *cli) lines(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	r, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: nothing to do, no arguments and no input", errUsage)
+	}
+	return lines, nil
+}
+
+func (c *cli) open() (io.ReadCloser, error) {
+	if c.input == "" || c.input == "-" {
+		return io.NopCloser(c.stdin), nil
+	}
+	return os.Open(c.input)
+}
+
+// printer hands results to the output, keeping the first write error
+func (c *cli) printer(err *error) func(*pb.OrderResponse) {
+	return func(res *pb.OrderResponse) {
+		if printErr := c.out.print(res); printErr != nil && *err == nil {
+			*err = printErr
+		}
+	}
+}
+
+func (c *cli) search(ctx context.Context, args []string) error {
+	names, err := c.lines(args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, streamTimeout)
+	defer cancel()
+	var printErr error
+	retried, err := runServerStream(ctx, c.client, names, c.printer(&printErr))
+	c.reportRetried(retried)
+	if err != nil {
+		return err
+	}
+	return printErr
+}
+
+func (c *cli) stream(ctx context.Context, args []string) error {
+	names, err := c.lines(args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, streamTimeout)
+	defer cancel()
+	var printErr error
+	retried, err := runBidiStream(ctx, c.client, names, sendInterval, c.printer(&printErr))
+	c.reportRetried(retried)
+	if err != nil {
+		return err
+	}
+	return printErr
+}
+
+func (c *cli) reportRetried(names []string) {
+	if len(names) > 0 {
+		fmt.Fprintf(c.stderr, "sent again after reconnecting: %v\n", strings.Join(names, ", "))
+	}
+}
+
+func (c *cli) order(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return c.upload(ctx)
+	}
+	if len(args) > 2 {
+		return fmt.Errorf("%w: order takes an item and a quantity, got %d arguments (quote names with spaces)", errUsage, len(args))
+	}
+	quantity := int64(1)
+	if len(args) == 2 {
+		var err error
+		if quantity, err = strconv.ParseInt(args[1], 10, 32); err != nil {
+			return fmt.Errorf("%w: quantity %q is not a number", errUsage, args[1])
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, callTimeout)
+	defer cancel()
+	order, err := c.client.PlaceOrder(ctx, &pb.PlaceOrderRequest{ItemName: args[0], Quantity: int32(quantity)})
+	if err != nil {
+		return err
+	}
+	return c.out.print(order)
+}
+
+// upload places the orders of a CSV read from the input in one call
+func (c *cli) upload(ctx context.Context) error {
+	r, err := c.open()
+	if err != nil {
+		return err
+	}
+	lines, err := readOrderLines(r)
+	r.Close()
+	if err != nil {
+		return fmt.Errorf("nothing ordered: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, streamTimeout)
+	defer cancel()
+	stream, err := c.client.UploadOrders(ctx)
+	if err != nil {
+		return err
+	}
+	// io.EOF from Send means the server ended the call, CloseAndRecv says why
+	sendOrderLines(lines, stream.Send)
+	summary, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	if err := c.out.print(summary); err != nil {
+		return err
+	}
+	if summary.LinesRejected > 0 {
+		return errPartial
+	}
+	return nil
+}
+
+func (c *cli) cancel(ctx context.Context, args []string) error {
+	return c.eachOrder(ctx, args, func(ctx context.Context, id string) (*pb.Order, error) {
+		return c.client.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: id})
+	})
+}
+
+func (c *cli) fulfill(ctx context.Context, args []string) error {
+	return c.eachOrder(ctx, args, func(ctx context.Context, id string) (*pb.Order, error) {
+		return c.client.FulfillOrder(ctx, &pb.FulfillOrderRequest{OrderId: id})
+	})
+}
+
+// eachOrder calls call for every order id given, printing the orders it
+// returns and carrying on past the ones it fails for
+func (c *cli) eachOrder(ctx context.Context, args []string, call func(context.Context, string) (*pb.Order, error)) error {
+	ids, err := c.lines(args)
+	if err != nil {
+		return err
+	}
+	var result error
+	for _, id := range ids {
+		callCtx, cancel := context.WithTimeout(ctx, callTimeout)
+		order, err := call(callCtx, id)
+		cancel()
+		if err != nil {
+			c.fail(id, err)
+			result = errPartial
+			continue
+		}
+		if err := c.out.print(order); err != nil {
+			return err
+		}
+	}
+	return result
+}
+
+func (c *cli) catalog(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("%w: catalog takes no arguments", errUsage)
+	}
+	// each page gets its own timeout, as each order of cancel does
+	req := &pb.ListItemsRequest{}
+	for {
+		pageCtx, cancel := context.WithTimeout(ctx, callTimeout)
+		res, err := c.admin.ListItems(pageCtx, req)
+		cancel()
+		if err != nil {
+			return err
+		}
+		for _, item := range res.Items {
+			if err := c.out.print(item); err != nil {
+				return err
+			}
+		}
+		if res.NextPageToken == "" {
+			return nil
+		}
+		req.PageToken = res.NextPageToken
+	}
+}
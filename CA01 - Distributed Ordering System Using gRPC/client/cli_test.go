@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// cancellingServer knows a single order
+type cancellingServer struct {
+	flakyServer
+}
+
+func (s *cancellingServer) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
+	if req.OrderId != "ORD-000001" {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return &pb.Order{OrderId: req.OrderId, ItemName: "kiwi", Quantity: 2, Status: pb.OrderStatus_CANCELLED}, nil
+}
+
+func (s *cancellingServer) FulfillOrder(ctx context.Context, req *pb.FulfillOrderRequest) (*pb.Order, error) {
+	if req.OrderId != "ORD-000001" {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return &pb.Order{OrderId: req.OrderId, ItemName: "kiwi", Quantity: 2, Status: pb.OrderStatus_FULFILLED}, nil
+}
+
+func runCLI(t *testing.T, format, stdin string, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	c := &cli{
+		client: dialFlaky(t, &cancellingServer{flakyServer{failAfter: -1}}),
+		out:    newOutput(&out, format),
+		stdin:  strings.NewReader(stdin),
+		stderr: &errOut,
+	}
+	code = c.run(args)
+	return code, out.String(), errOut.String()
+}
+
+func TestCLISearchOutputs(t *testing.T) {
+	code, out, _ := runCLI(t, "json", "red apple\n\n# skipped\nkiwi\n", "search")
+	if code != 0 {
+		t.Fatalf("exit status %d", code)
+	}
+	var queries []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var res struct{ Query string }
+		if err := json.Unmarshal([]byte(line), &res); err != nil {
+			t.Fatalf("%q is not JSON: %v", line, err)
+		}
+		queries = append(queries, res.Query)
+	}
+	if strings.Join(queries, ",") != "red apple,kiwi" {
+		t.Errorf("searched for %q", queries)
+	}
+
+	_, out, _ = runCLI(t, "table", "", "search", "kiwi", "pear")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "QUERY") || !strings.HasPrefix(lines[2], "pear") {
+		t.Errorf("table output:\n%v", out)
+	}
+}
+
+func TestCLIExitStatus(t *testing.T) {
+	tests := []struct {
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{[]string{"cancel", "ORD-000001"}, 0, "ORD-000001 CANCELLED: 2 x kiwi", ""},
+		{[]string{"cancel", "ORD-000001", "ORD-000002"}, 1, "ORD-000001 CANCELLED", "ORD-000002: NotFound: order not found"},
+		{[]string{"fulfill", "ORD-000001", "ORD-000002"}, 1, "ORD-000001 FULFILLED", "ORD-000002: NotFound: order not found"},
+		{[]string{"order", "kiwi", "two"}, 2, "", "quantity \"two\" is not a number"},
+		{[]string{"search"}, 2, "", "nothing to do"},
+		{[]string{"refund"}, 2, "", "unknown command"},
+		{[]string{"help"}, 0, "commands:", ""},
+	}
+	for _, tt := range tests {
+		code, out, errOut := runCLI(t, "text", "", tt.args...)
+		if code != tt.code || !strings.Contains(out, tt.stdout) || !strings.Contains(errOut, tt.stderr) {
+			t.Errorf("%v: exit %d, stdout %q, stderr %q", tt.args, code, out, errOut)
+		}
+	}
+}
+
+// slowCatalog serves one item per page, each after delay
+type slowCatalog struct {
+	pb.CatalogAdminServiceServer
+	pages int
+	delay time.Duration
+}
+
+func (s *slowCatalog) ListItems(ctx context.Context, req *pb.ListItemsRequest) (*pb.ListItemsResponse, error) {
+	time.Sleep(s.delay)
+	page, _ := strconv.Atoi(req.PageToken)
+	res := &pb.ListItemsResponse{Items: []*pb.CatalogItem{{Id: "item-" + strconv.Itoa(page+1), Name: "kiwi"}}}
+	if page+1 < s.pages {
+		res.NextPageToken = strconv.Itoa(page + 1)
+	}
+	return res, nil
+}
+
+func TestCLICatalogTimesEachPage(t *testing.T) {
+	timeout := callTimeout
+	callTimeout = 200 * time.Millisecond
+	defer func() { callTimeout = timeout }()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	// together the pages take longer than one call may
+	pb.RegisterCatalogAdminServiceServer(s, &slowCatalog{pages: 4, delay: 100 * time.Millisecond})
+	go s.Serve(lis)
+	defer s.Stop()
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var out, errOut bytes.Buffer
+	c := &cli{admin: pb.NewCatalogAdminServiceClient(conn), out: newOutput(&out, "text"), stderr: &errOut}
+	if code := c.run([]string{"catalog"}); code != 0 || strings.Count(out.String(), "kiwi") != 4 {
+		t.Errorf("exit %d, stdout %q, stderr %q", code, out.String(), errOut.String())
+	}
+	if items, err := catalogItems(c.admin); err != nil || len(items) != 4 {
+		t.Errorf("listed %d items, %v", len(items), err)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"log"
+
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+func printResponse(res *pb.OrderResponse) {
+	switch result := res.Result.(type) {
+	case *pb.OrderResponse_Found:
+		item := result.Found
+		log.Printf("Item found for %q: #%d %v (id: %v, match: %v, score: %.2f, available: %d)",
+			res.Query, item.CatalogIndex, item.ItemName, item.ItemId, item.MatchKind, item.Score, item.QuantityAvailable)
+	case *pb.OrderResponse_Order:
+		log.Printf("Order placed for %q: %v", res.Query, result.Order)
+	case *pb.OrderResponse_Cart:
+		log.Printf("Cart, %d units:", result.Cart.TotalQuantity)
+		for _, line := range result.Cart.Lines {
+			log.Printf("  %d x %v (id: %v, available: %d)", line.Quantity, line.ItemName, line.ItemId, line.QuantityAvailable)
+		}
+	case *pb.OrderResponse_Error:
+		log.Printf("Request for %q failed", res.Query)
+		printStatus(status.FromProto(result.Error))
+	default:
+		log.Println(res)
+	}
+}
+
+// printStatus logs a gRPC status together with any errdetails it carries
+func printStatus(st *status.Status) {
+	log.Printf("%v: %v", st.Code(), st.Message())
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				log.Printf("  bad field %v: %v", v.Field, v.Description)
+			}
+		case *errdetails.QuotaFailure:
+			for _, v := range d.Violations {
+				log.Printf("  quota exceeded for %v: %v", v.Subject, v.Description)
+			}
+		case *errdetails.ErrorInfo:
+			log.Printf("  reason: %v", d.Reason)
+			if suggestions, ok := d.Metadata["suggestions"]; ok {
+				log.Printf("  did you mean: %v", suggestions)
+			}
+		case *errdetails.PreconditionFailure:
+			for _, v := range d.Violations {
+				log.Printf("  %v of %v: %v", v.Type, v.Subject, v.Description)
+			}
+		case *errdetails.ResourceInfo:
+			log.Printf("  %v %q: %v", d.ResourceType, d.ResourceName, d.Description)
+		default:
+			log.Printf("  %v", d)
+		}
+	}
+}
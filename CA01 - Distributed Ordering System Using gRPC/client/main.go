@@ -1,51 +1,108 @@
 package main
 
 import (
-	"fmt"
+	"flag"
 	"log"
-	"strings"
+	"os"
 
+	"github.com/m-hariri/basic-go-grpc/auth"
+	"github.com/m-hariri/basic-go-grpc/config"
+	"github.com/m-hariri/basic-go-grpc/logging"
 	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"github.com/m-hariri/basic-go-grpc/tlsutil"
+	"github.com/m-hariri/basic-go-grpc/tracing"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-const (
-	port = ":8080"
+// call deadlines and pacing, set from the configuration
+var (
+	callTimeout   = config.DefaultClient().Timeout
+	streamTimeout = config.DefaultClient().StreamTimeout
+	sendInterval  = config.DefaultClient().SendInterval
 )
 
 func main() {
-	conn, err := grpc.Dial("localhost"+port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	cfg, err := config.LoadClient(os.Args[1:], os.LookupEnv)
+	if err == flag.ErrHelp {
+		return
+	}
+	if cfg != nil && cfg.PrintConfig {
+		config.Print(os.Stdout, cfg.Redacted())
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.PrintConfig {
+		return
+	}
+	level, _ := logging.ParseLevel(cfg.LogLevel)
+	logging.SetLevel(level)
+	if err := logging.SetFormat(cfg.LogFormat); err != nil {
+		log.Fatal(err)
+	}
+	// closed before os.Exit as well, which skips deferred calls
+	closeTraces := func() error { return nil }
+	if cfg.TraceFile != "" {
+		exporter, err := tracing.NewFileExporter(cfg.TraceFile, "order-client")
+		if err != nil {
+			log.Fatalf("Failed to open trace file: %v", err)
+		}
+		tracing.SetExporter(exporter)
+		closeTraces = exporter.Close
+		defer exporter.Close()
+	}
+	callTimeout = cfg.Timeout
+	streamTimeout = cfg.StreamTimeout
+	sendInterval = cfg.SendInterval
+	maxRetries = cfg.Retries
+
+	creds := insecure.NewCredentials()
+	if cfg.TLS.On() {
+		tlsConfig, err := tlsutil.ClientConfig(cfg.TLS.CA, cfg.TLS.Cert, cfg.TLS.Key, cfg.TLS.ServerName)
+		if err != nil {
+			log.Fatalf("Failed to set up TLS: %v", err)
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(serviceConfig(cfg.Retries)),
+		// outside the retries, so each attempt is not a span of its own
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(tracing.StreamClientInterceptor()),
+	}
+	if cfg.Token != "" {
+		// plaintext is allowed for local testing, where the token is no secret
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(auth.Bearer(cfg.Token, cfg.TLS.On())))
+	}
+	conn, err := grpc.Dial(cfg.Target, dialOpts...)
 	if err != nil {
 		log.Fatalf("Connection failed: %v", err)
 	}
 	defer conn.Close()
 
 	client := pb.NewOrderServiceClient(conn)
-	for {
-		userInput := 0
-		fmt.Printf("Please enter 1 for Server Streaming and 2 for Bidirectional Streaming and 0 to exit: ")
-		fmt.Scan(&userInput)
-
-		if userInput == 0 { break }
-
-		var inputNmaes string
-		fmt.Printf("please enter Order from this list(note values must be comma seperated and use no space):{banana, apple, orange, grape, red apple, kiwi, mango, pear, cherry, green apple} \n")
-		fmt.Scan(&inputNmaes)
-		if inputNmaes == "" { continue }
-
-		names := strings.Split(inputNmaes, ",")
-
-		orders := &pb.NamesList{
-			Names: names,
-		}
-
-		if userInput == 1 {
-			callGetOrderServerStream(client, orders)
-		} else if userInput == 2 {
-			callGetOrderBidirectionalStream(client, orders)
-		} else {
-			break
+	adminClient := pb.NewCatalogAdminServiceClient(conn)
+	if len(cfg.Args) > 0 {
+		c := &cli{
+			client: client,
+			admin:  adminClient,
+			out:    newOutput(os.Stdout, cfg.Output),
+			input:  cfg.Input,
+			stdin:  os.Stdin,
+			stderr: os.Stderr,
 		}
+		code := c.run(cfg.Args)
+		conn.Close()
+		closeTraces()
+		os.Exit(code)
+	}
+	r, err := newREPL(client, adminClient, cfg.History)
+	if err != nil {
+		log.Fatalf("Could not start the interactive client: %v", err)
 	}
+	r.run()
 }
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// output writes command results as text, an aligned table or one JSON object
+// per line
+type output struct {
+	format string
+	w      io.Writer
+	table  *tabwriter.Writer
+	header []string // columns of the table so far
+}
+
+func newOutput(w io.Writer, format string) *output {
+	o := &output{format: format, w: w}
+	if format == "table" {
+		o.table = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		o.w = o.table
+	}
+	return o
+}
+
+var jsonOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+func (o *output) print(m proto.Message) error {
+	switch o.format {
+	case "json":
+		data, err := jsonOptions.Marshal(m)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(o.w, "%s\n", data)
+		return err
+	case "table":
+		for _, row := range tableRows(m) {
+			if err := o.row(row.header, row.cells); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		_, err := fmt.Fprintln(o.w, text(m))
+		return err
+	}
+}
+
+// row writes the header first and again whenever the kind of row changes
+func (o *output) row(header, cells []string) error {
+	if strings.Join(header, "\t") != strings.Join(o.header, "\t") {
+		o.header = header
+		if _, err := fmt.Fprintln(o.w, strings.Join(header, "\t")); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(o.w, strings.Join(cells, "\t"))
+	return err
+}
+
+func (o *output) flush() error {
+	if o.table != nil {
+		return o.table.Flush()
+	}
+	return nil
+}
+
+type tableRow struct {
+	header, cells []string
+}
+
+var (
+	responseHeader = []string{"QUERY", "RESULT", "ITEM_ID", "ITEM", "DETAIL"}
+	orderHeader    = []string{"ORDER_ID", "STATUS", "ITEM", "QUANTITY"}
+	itemHeader     = []string{"ID", "NAME", "QUANTITY"}
+	lineHeader     = []string{"LINE", "REFERENCE", "RESULT", "DETAIL"}
+)
+
+func tableRows(m proto.Message) []tableRow {
+	switch m := m.(type) {
+	case *pb.OrderResponse:
+		switch result := m.Result.(type) {
+		case *pb.OrderResponse_Found:
+			item := result.Found
+			return []tableRow{{responseHeader, []string{m.Query, item.MatchKind.String(), item.ItemId, item.ItemName,
+				fmt.Sprintf("score %.2f, %d available", item.Score, item.QuantityAvailable)}}}
+		case *pb.OrderResponse_Order:
+			order := result.Order
+			return []tableRow{{responseHeader, []string{m.Query, "ORDERED", order.ItemId, order.ItemName, order.OrderId}}}
+		case *pb.OrderResponse_Error:
+			st := status.FromProto(result.Error)
+			return []tableRow{{responseHeader, []string{m.Query, st.Code().String(), "", "", statusDetail(st)}}}
+		}
+	case *pb.Order:
+		return []tableRow{{orderHeader, []string{m.OrderId, m.Status.String(), orderItem(m), fmt.Sprint(m.Quantity)}}}
+	case *pb.CatalogItem:
+		return []tableRow{{itemHeader, []string{m.Id, m.Name, fmt.Sprint(m.Quantity)}}}
+	case *pb.UploadSummary:
+		var rows []tableRow
+		for _, line := range m.Accepted {
+			rows = append(rows, tableRow{lineHeader, []string{fmt.Sprint(line.Line), line.Reference, "ACCEPTED",
+				fmt.Sprintf("%v: %d x %v", line.Order.OrderId, line.Order.Quantity, line.Order.ItemName)}})
+		}
+		for _, line := range m.Rejected {
+			st := status.FromProto(line.Error)
+			rows = append(rows, tableRow{lineHeader, []string{fmt.Sprint(line.Line), line.Reference, st.Code().String(), statusDetail(st)}})
+		}
+		return append(rows, tableRow{lineHeader, []string{"total", fmt.Sprint(m.LinesReceived), "",
+			fmt.Sprintf("%d accepted for %d units, %d rejected", m.LinesAccepted, m.UnitsOrdered, m.LinesRejected)}})
+	}
+	return []tableRow{{[]string{"VALUE"}, []string{text(m)}}}
+}
+
+func text(m proto.Message) string {
+	switch m := m.(type) {
+	case *pb.OrderResponse:
+		switch result := m.Result.(type) {
+		case *pb.OrderResponse_Found:
+			item := result.Found
+			return fmt.Sprintf("%v: %v (%v), %v match, score %.2f, %d available",
+				m.Query, item.ItemName, item.ItemId, item.MatchKind, item.Score, item.QuantityAvailable)
+		case *pb.OrderResponse_Order:
+			return fmt.Sprintf("%v: ordered, %v", m.Query, text(result.Order))
+		case *pb.OrderResponse_Error:
+			return fmt.Sprintf("%v: %v", m.Query, statusText(status.FromProto(result.Error)))
+		}
+	case *pb.Order:
+		return fmt.Sprintf("%v %v: %d x %v", m.OrderId, m.Status, m.Quantity, orderItem(m))
+	case *pb.CatalogItem:
+		return fmt.Sprintf("%v: %v (%d available)", m.Id, m.Name, m.Quantity)
+	case *pb.UploadSummary:
+		lines := []string{fmt.Sprintf("%d lines: %d accepted for %d units, %d rejected",
+			m.LinesReceived, m.LinesAccepted, m.UnitsOrdered, m.LinesRejected)}
+		for _, line := range m.Accepted {
+			lines = append(lines, fmt.Sprintf("  line %d (%v): %v", line.Line, line.Reference, text(line.Order)))
+		}
+		for _, line := range m.Rejected {
+			lines = append(lines, fmt.Sprintf("  line %d (%v): %v", line.Line, line.Reference, statusText(status.FromProto(line.Error))))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return fmt.Sprint(m)
+}
+
+func orderItem(order *pb.Order) string {
+	if order.ItemName != "" {
+		return order.ItemName
+	}
+	names := make([]string, len(order.Lines))
+	for i, line := range order.Lines {
+		names[i] = fmt.Sprintf("%d %v", line.Quantity, line.ItemName)
+	}
+	return strings.Join(names, ", ")
+}
+
+// statusText puts a status and the gist of its details on one line
+func statusText(st *status.Status) string {
+	return st.Code().String() + ": " + statusDetail(st)
+}
+
+// statusDetail is statusText without the code
+func statusDetail(st *status.Status) string {
+	parts := []string{st.Message()}
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if suggestions, ok := d.Metadata["suggestions"]; ok {
+				parts = append(parts, "did you mean: "+suggestions)
+			}
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				if v.Description == st.Message() {
+					parts = append(parts, "field "+v.Field)
+				} else {
+					parts = append(parts, v.Field+": "+v.Description)
+				}
+			}
+		}
+	}
+	return strings.Join(parts, "; ")
+}
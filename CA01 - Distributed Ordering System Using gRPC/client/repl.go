@@ -0,0 +1,552 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chzyer/readline"
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"github.com/m-hariri/basic-go-grpc/tracing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const replHelp = `commands:
+  search name[, name ...]   look names up on a server streaming call
+  stream name[, name ...]   look names up on a bidirectional stream
+  order item [x quantity]   order quantity (default 1) of the item with exactly this name
+  get order-id              show an order
+  cancel order-id           cancel an order
+  fulfill order-id          mark a confirmed order as shipped (admin role)
+  catalog                   list the catalog and refresh the item names TAB completes
+  restock item-id quantity  add stock to an item
+  upload file               place the orders of a CSV file of item,quantity[,reference] rows
+
+  open                      open a session, a bidirectional stream kept open between commands
+  send item [x quantity]    look an item up in the session, or order quantity of it
+  add item [x quantity]     put quantity (default 1) of an item in the session's cart
+  set item x quantity       change the quantity of an item in the cart, 0 takes it out
+  remove item               take an item out of the cart
+  cart                      show the cart
+  checkout                  order everything in the cart at once
+  close                     close the session once every request is answered
+
+  help                      show this help
+  exit                      leave, as do Ctrl-D and Ctrl-C on an empty line
+
+Item names may contain spaces; TAB completes them and the commands. Session
+responses are printed as they arrive. If a session breaks, open resumes it.
+`
+
+// repl is the interactive client: one command per line, with history and
+// completion
+type repl struct {
+	client pb.OrderServiceClient
+	admin  pb.CatalogAdminServiceClient
+	rl     *readline.Instance
+	items  *completer
+	// session is the open session, or the one that broke so open can resume it
+	session *replSession
+}
+
+var replCommands = map[string]func(*repl, string) error{
+	"search":   (*repl).search,
+	"stream":   (*repl).stream,
+	"order":    (*repl).order,
+	"get":      (*repl).get,
+	"cancel":   (*repl).cancel,
+	"fulfill":  (*repl).fulfill,
+	"catalog":  (*repl).catalog,
+	"restock":  (*repl).restock,
+	"upload":   (*repl).upload,
+	"open":     (*repl).open,
+	"send":     (*repl).send,
+	"add":      (*repl).add,
+	"set":      (*repl).set,
+	"remove":   (*repl).remove,
+	"cart":     (*repl).cart,
+	"checkout": (*repl).checkout,
+	"close":    (*repl).close,
+}
+
+// itemCommands take an item name, search and stream a comma separated list
+var itemCommands = map[string]bool{
+	"search": true, "stream": true, "order": true, "send": true, "add": true, "set": true, "remove": true,
+}
+
+// newREPL reads commands from the terminal, keeping their history in
+// historyFile unless it is empty
+func newREPL(client pb.OrderServiceClient, admin pb.CatalogAdminServiceClient, historyFile string) (*repl, error) {
+	r := &repl{client: client, admin: admin, items: &completer{}}
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:            "order> ",
+		HistoryFile:       historyFile,
+		HistorySearchFold: true,
+		AutoComplete:      r.items,
+		InterruptPrompt:   "^C",
+		EOFPrompt:         "exit",
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.rl = rl
+	// responses printed while a command is typed redraw the prompt below them
+	log.SetOutput(rl.Stderr())
+	return r, nil
+}
+
+// run reads and executes commands until exit or end of input
+func (r *repl) run() {
+	defer r.rl.Close()
+	if names, err := r.itemNames(); err != nil {
+		logging.Warnf("Item names will not complete, could not list the catalog: %v", err)
+	} else {
+		r.items.setNames(names)
+	}
+	fmt.Fprint(r.rl.Stdout(), "Type help for the commands, TAB completes them and item names.\n")
+	for {
+		line, err := r.rl.Readline()
+		if err == readline.ErrInterrupt {
+			if line == "" {
+				break
+			}
+			continue
+		}
+		if err != nil {
+			// io.EOF after Ctrl-D
+			break
+		}
+		if !r.exec(line) {
+			break
+		}
+	}
+	if r.session.active() {
+		r.close("")
+	}
+}
+
+// exec runs one command line and reports whether to go on
+func (r *repl) exec(line string) bool {
+	name, args := splitCommand(line)
+	switch name {
+	case "":
+		return true
+	case "exit", "quit":
+		return false
+	case "help":
+		fmt.Fprint(r.rl.Stdout(), replHelp)
+		return true
+	}
+	command, ok := replCommands[name]
+	if !ok {
+		log.Printf("Unknown command %q, type help for the commands", name)
+		return true
+	}
+	if err := command(r, args); err != nil {
+		log.Println(err)
+	}
+	return true
+}
+
+// splitCommand splits a line into the command and the rest of it
+func splitCommand(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if i := strings.IndexAny(line, " \t"); i >= 0 {
+		return line[:i], strings.TrimSpace(line[i+1:])
+	}
+	return line, ""
+}
+
+// nameList splits a comma separated list of names
+func nameList(args string) []string {
+	var names []string
+	for _, name := range strings.Split(args, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// itemQuantity splits an item name from the quantity after an " x ", as in
+// "iphone 15 x 2". Without one the whole text is the name: item names may end
+// in numbers.
+func itemQuantity(args string, quantity int32) (string, int32, error) {
+	if i := strings.LastIndex(strings.ToLower(args), " x "); i >= 0 {
+		if n, err := strconv.ParseInt(strings.TrimSpace(args[i+3:]), 10, 32); err == nil {
+			args, quantity = strings.TrimSpace(args[:i]), int32(n)
+		}
+	}
+	if args == "" {
+		return "", 0, errors.New("no item given")
+	}
+	return args, quantity, nil
+}
+
+func (r *repl) search(args string) error {
+	names := nameList(args)
+	if len(names) == 0 {
+		return errors.New("usage: search name[, name ...]")
+	}
+	callGetOrderServerStream(r.client, &pb.NamesList{Names: names})
+	return nil
+}
+
+func (r *repl) stream(args string) error {
+	names := nameList(args)
+	if len(names) == 0 {
+		return errors.New("usage: stream name[, name ...]")
+	}
+	callGetOrderBidirectionalStream(r.client, &pb.NamesList{Names: names})
+	return nil
+}
+
+func (r *repl) order(args string) error {
+	name, quantity, err := itemQuantity(args, 1)
+	if err != nil {
+		return errors.New("usage: order item [x quantity]")
+	}
+	callPlaceOrder(r.client, name, quantity)
+	return nil
+}
+
+func (r *repl) get(args string) error {
+	if args == "" {
+		return errors.New("usage: get order-id")
+	}
+	callGetOrder(r.client, args)
+	return nil
+}
+
+func (r *repl) cancel(args string) error {
+	if args == "" {
+		return errors.New("usage: cancel order-id")
+	}
+	callCancelOrder(r.client, args)
+	return nil
+}
+
+func (r *repl) fulfill(args string) error {
+	if args == "" {
+		return errors.New("usage: fulfill order-id")
+	}
+	callFulfillOrder(r.client, args)
+	return nil
+}
+
+func (r *repl) catalog(args string) error {
+	if names := callListItems(r.admin); names != nil {
+		r.items.setNames(names)
+	}
+	return nil
+}
+
+func (r *repl) itemNames() ([]string, error) {
+	items, err := catalogItems(r.admin)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+	return names, nil
+}
+
+func (r *repl) restock(args string) error {
+	fields := strings.Fields(args)
+	if len(fields) != 2 {
+		return errors.New("usage: restock item-id quantity")
+	}
+	quantity, err := strconv.ParseInt(fields[1], 10, 32)
+	if err != nil {
+		return fmt.Errorf("quantity %q is not a number", fields[1])
+	}
+	callRestockItem(r.admin, fields[0], int32(quantity))
+	return nil
+}
+
+func (r *repl) upload(args string) error {
+	if args == "" {
+		return errors.New("usage: upload file")
+	}
+	callUploadOrders(r.client, args)
+	return nil
+}
+
+// open starts a session, resuming the previous one if its stream broke
+func (r *repl) open(args string) error {
+	if r.session.active() {
+		return errors.New("a session is open already")
+	}
+	previous := r.session
+	if previous != nil && previous.err == nil {
+		// the server finished it, there is nothing to resume
+		previous = nil
+	}
+	sess, err := openSession(r.client, previous)
+	if err != nil {
+		r.session = nil
+		log.Printf("Could not open session")
+		printStatus(status.Convert(err))
+		return nil
+	}
+	r.session = sess
+	return nil
+}
+
+func (r *repl) send(args string) error {
+	name, quantity, err := itemQuantity(args, 0)
+	if err != nil {
+		return errors.New("usage: send item [x quantity]")
+	}
+	return r.request(&pb.OrderRequest{Name: name, Quantity: quantity})
+}
+
+func (r *repl) add(args string) error {
+	name, quantity, err := itemQuantity(args, 1)
+	if err != nil {
+		return errors.New("usage: add item [x quantity]")
+	}
+	return r.request(&pb.OrderRequest{Cart: &pb.OrderRequest_AddItem{AddItem: &pb.CartAddItem{Name: name, Quantity: quantity}}})
+}
+
+func (r *repl) set(args string) error {
+	name, quantity, err := itemQuantity(args, -1)
+	if err != nil || quantity < 0 {
+		return errors.New("usage: set item x quantity")
+	}
+	return r.request(&pb.OrderRequest{Cart: &pb.OrderRequest_SetQuantity{SetQuantity: &pb.CartSetQuantity{Name: name, Quantity: quantity}}})
+}
+
+func (r *repl) remove(args string) error {
+	if args == "" {
+		return errors.New("usage: remove item")
+	}
+	return r.request(&pb.OrderRequest{Cart: &pb.OrderRequest_RemoveItem{RemoveItem: &pb.CartRemoveItem{Name: args}}})
+}
+
+func (r *repl) cart(args string) error {
+	return r.request(&pb.OrderRequest{Cart: &pb.OrderRequest_ViewCart{ViewCart: &pb.CartView{}}})
+}
+
+func (r *repl) checkout(args string) error {
+	return r.request(&pb.OrderRequest{Cart: &pb.OrderRequest_Checkout{Checkout: &pb.CartCheckout{}}})
+}
+
+// request sends req on the session; its responses are printed when they come
+func (r *repl) request(req *pb.OrderRequest) error {
+	if !r.session.active() {
+		return errors.New("no session is open, open one first")
+	}
+	if err := r.session.send(req); err != nil {
+		// the receiving side reports why the stream ended
+		return errors.New("the session broke, open resumes it")
+	}
+	return nil
+}
+
+func (r *repl) close(args string) error {
+	if !r.session.active() {
+		return errors.New("no session is open")
+	}
+	if err := r.session.close(); err != nil {
+		logging.For(r.session.ctx).Infof("Session ended with an error")
+		printStatus(status.Convert(err))
+	}
+	r.session = nil
+	return nil
+}
+
+// replSession is a bidirectional stream kept open between commands. A
+// goroutine prints its responses as they arrive. A session is one trace, a
+// resumed one continues the trace of the session it resumes.
+type replSession struct {
+	stream pb.OrderService_GetOrderBidirectionalStreamingClient
+	ctx    context.Context
+	cancel context.CancelFunc
+	span   *tracing.Span
+	token  string
+
+	mtx   sync.Mutex
+	seq   uint64 // last request sent
+	acked uint64 // every response up to this request arrived
+	done  chan struct{}
+	err   error // why the stream ended, nil if the server finished it
+}
+
+// openSession starts a session, resuming previous if it is set
+func openSession(client pb.OrderServiceClient, previous *replSession) (*replSession, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if previous != nil {
+		ctx = tracing.WithRemote(ctx, previous.span.SpanContext)
+		ctx = metadata.AppendToOutgoingContext(ctx,
+			sessionTokenKey, previous.token, sessionAckKey, strconv.FormatUint(previous.acknowledged(), 10))
+	}
+	ctx, span := tracing.Start(ctx, "session", tracing.Internal)
+	fail := func(err error) (*replSession, error) {
+		cancel()
+		span.End(err)
+		return nil, err
+	}
+	stream, err := client.GetOrderBidirectionalStreaming(ctx)
+	if err != nil {
+		return fail(err)
+	}
+	header, err := stream.Header()
+	if err == nil && header == nil {
+		// the call failed before it began, Recv says why
+		_, err = stream.Recv()
+	}
+	if err != nil {
+		return fail(err)
+	}
+	s := &replSession{stream: stream, ctx: ctx, cancel: cancel, span: span, done: make(chan struct{})}
+	if v := header.Get(sessionTokenKey); len(v) > 0 {
+		s.token = v[0]
+		s.ctx = logging.WithAttrs(s.ctx, "session", s.token)
+	}
+	if v := header.Get(sessionSeqKey); len(v) > 0 {
+		s.seq, _ = strconv.ParseUint(v[0], 10, 64)
+	}
+	if previous != nil {
+		s.acked = previous.acknowledged()
+		logging.For(s.ctx).Infof("Session resumed after request %d", s.seq)
+	} else {
+		logging.For(s.ctx).Infof("Session opened")
+	}
+	go s.receive()
+	return s, nil
+}
+
+// active reports whether s is open; a nil session is not
+func (s *replSession) active() bool {
+	if s == nil {
+		return false
+	}
+	select {
+	case <-s.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (s *replSession) acknowledged() uint64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.acked
+}
+
+func (s *replSession) receive() {
+	defer close(s.done)
+	defer func() { s.span.End(s.err) }()
+	for {
+		res, err := s.stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			s.err = err
+			if status.Code(err) != codes.Canceled {
+				logging.For(s.ctx).Infof("Session broke, open resumes it")
+				printStatus(status.Convert(err))
+			}
+			return
+		}
+		if res.Last {
+			s.mtx.Lock()
+			s.acked = res.Seq
+			s.mtx.Unlock()
+		}
+		printResponse(res)
+	}
+}
+
+func (s *replSession) send(req *pb.OrderRequest) error {
+	s.mtx.Lock()
+	s.seq++
+	req.Seq, req.Ack = s.seq, s.acked
+	s.mtx.Unlock()
+	return s.stream.Send(req)
+}
+
+// close waits up to the stream timeout for the outstanding responses and
+// ends the session for good
+func (s *replSession) close() error {
+	defer s.cancel()
+	if err := s.stream.CloseSend(); err != nil {
+		return err
+	}
+	select {
+	case <-s.done:
+		return s.err
+	case <-time.After(streamTimeout):
+		return status.Error(codes.DeadlineExceeded, "responses still outstanding when the session was closed")
+	}
+}
+
+// completer completes command names and, after the commands that take items,
+// the catalog item names last listed
+type completer struct {
+	mtx   sync.Mutex
+	names []string
+}
+
+func (c *completer) setNames(names []string) {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.names = sorted
+}
+
+// Do returns the endings of the candidates for the word before pos and the
+// length of that word, as readline.AutoCompleter wants
+func (c *completer) Do(line []rune, pos int) ([][]rune, int) {
+	typed := string(line[:pos])
+	command, rest := splitCommand(typed)
+	if command == strings.TrimLeft(typed, " \t") {
+		// still typing the command
+		var commands []string
+		for name := range replCommands {
+			commands = append(commands, name)
+		}
+		commands = append(commands, "help", "exit")
+		sort.Strings(commands)
+		return endings(commands, command, " ")
+	}
+	if !itemCommands[command] {
+		return nil, 0
+	}
+	if command == "search" || command == "stream" {
+		if i := strings.LastIndex(rest, ","); i >= 0 {
+			rest = strings.TrimLeft(rest[i+1:], " \t")
+		}
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return endings(c.names, rest, "")
+}
+
+// endings returns what completes prefix to each of candidates, followed by
+// suffix
+func endings(candidates []string, prefix, suffix string) ([][]rune, int) {
+	var matches [][]rune
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			matches = append(matches, []rune(candidate[len(prefix):]+suffix))
+		}
+	}
+	return matches, len([]rune(prefix))
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func complete(c *completer, line string) []string {
+	endings, _ := c.Do([]rune(line), len([]rune(line)))
+	var got []string
+	for _, ending := range endings {
+		got = append(got, string(ending))
+	}
+	return got
+}
+
+func TestCompleter(t *testing.T) {
+	c := &completer{}
+	c.setNames([]string{"red apple", "apple", "green apple", "grape"})
+	for _, tt := range []struct {
+		line string
+		want []string
+	}{
+		{"ca", []string{"ncel ", "rt ", "talog "}},
+		{"fu", []string{"lfill "}},
+		{"order gr", []string{"ape", "een apple"}},
+		{"order red", []string{" apple"}},
+		{"search apple, gre", []string{"en apple"}},
+		{"stream red apple,", []string{"apple", "grape", "green apple", "red apple"}},
+		{"get gr", nil},
+	} {
+		if got := complete(c, tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q completes to %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestItemQuantity(t *testing.T) {
+	for _, tt := range []struct {
+		args     string
+		name     string
+		quantity int32
+	}{
+		{"iphone 15", "iphone 15", 1},
+		{"iphone 15 x 2", "iphone 15", 2},
+		{"red apple X 3", "red apple", 3},
+		{"box x large", "box x large", 1},
+		{"kiwi x 0", "kiwi", 0},
+	} {
+		name, quantity, err := itemQuantity(tt.args, 1)
+		if err != nil || name != tt.name || quantity != tt.quantity {
+			t.Errorf("%q: got %q x %d, %v; want %q x %d", tt.args, name, quantity, err, tt.name, tt.quantity)
+		}
+	}
+	if _, _, err := itemQuantity("", 1); err == nil {
+		t.Error("no item accepted")
+	}
+}
+
+func TestREPLSession(t *testing.T) {
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+
+	srv := &flakyServer{failAfter: -1}
+	r := &repl{client: dialFlaky(t, srv), items: &completer{}}
+	for _, line := range []string{"send kiwi", "open", "open", "send red apple x 0", "send kiwi", "close", "close"} {
+		if !r.exec(line) {
+			t.Fatalf("%q ended the REPL", line)
+		}
+	}
+	for _, want := range []string{
+		"no session is open, open one first",
+		"a session is open already",
+		`Item found for "red apple"`,
+		`Item found for "kiwi"`,
+		"no session is open\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%v", want, out.String())
+		}
+	}
+	if r.exec("exit") {
+		t.Error("exit did not end the REPL")
+	}
+}
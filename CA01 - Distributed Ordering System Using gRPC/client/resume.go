@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadata keys of the bidirectional stream's session, see ordering.proto
+const (
+	sessionTokenKey = "session-token"
+	sessionSeqKey   = "session-seq"
+	sessionAckKey   = "session-ack"
+)
+
+// tracker follows which names of a session are still unanswered. Responses
+// carry the one-based sequence number of the name they answer and mark the
+// last response to it; the server answers names in order.
+type tracker struct {
+	mtx     sync.Mutex
+	names   []string
+	sent    int // names[:sent] went out on the current or an earlier stream
+	acked   int // names[:acked] are fully answered
+	partial int // responses received for names[acked]
+	skip    int // of those, how many a new stream will repeat
+	retried []string
+}
+
+func newTracker(names []string) *tracker {
+	return &tracker{names: names}
+}
+
+// answer records a response to names[seq-1] and reports whether it is new
+// rather than one repeated by a resumed stream
+func (t *tracker) answer(seq int, last bool) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if seq <= t.acked {
+		return false
+	}
+	if seq > t.acked+1 {
+		// responses for the names in between arrived on a broken stream
+		t.acked, t.partial, t.skip = seq-1, 0, 0
+	}
+	repeated := t.skip > 0
+	if repeated {
+		t.skip--
+	}
+	t.partial++
+	if last {
+		t.acked, t.partial, t.skip = seq, 0, 0
+	}
+	return !repeated
+}
+
+// answered is the number of names fully answered
+func (t *tracker) answered() int {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.acked
+}
+
+// markSent records that names[i] went out and reports whether it was sent for
+// the first time
+func (t *tracker) markSent(i int) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if i < t.sent {
+		return false
+	}
+	t.sent = i + 1
+	return true
+}
+
+// restart prepares a new stream that carries on from names[from], or from the
+// first unanswered name if that comes later. It returns the index to start
+// from and records names that go out again as retried.
+func (t *tracker) restart(from int) (int, []string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if from < t.acked {
+		from = t.acked
+	}
+	// the new stream starts over with the responses to names[acked]
+	t.skip, t.partial = t.partial, 0
+	if from < t.sent {
+		replay := t.names[from:t.sent]
+		logging.Infof("Sending %v again", replay)
+		t.retried = append(t.retried, replay...)
+	}
+	return from, t.names[from:]
+}
+
+// resume runs attempt until it succeeds, fails for good or ctx ends, backing
+// off between attempts
+func resume(ctx context.Context, attempt func(context.Context) error) error {
+	for retry := 0; ; retry++ {
+		err := attempt(ctx)
+		if err == nil || !retryable(err) || retry >= maxRetries {
+			return err
+		}
+		logging.For(ctx).Warnf("Stream broke (%v), reconnecting", err)
+		if !backoff(ctx, retry) {
+			return err
+		}
+	}
+}
+
+// runServerStream looks names up on a server streaming call, reconnecting
+// with the still unanswered names if the server goes away
+func runServerStream(ctx context.Context, client pb.OrderServiceClient, names []string, onResponse func(*pb.OrderResponse)) (retried []string, err error) {
+	t := newTracker(names)
+	err = resume(ctx, func(ctx context.Context) error {
+		first, rest := t.restart(0)
+		t.markSent(len(names) - 1)
+		stream, err := client.GetOrderServerStreaming(ctx, &pb.NamesList{Names: rest})
+		if err != nil {
+			return err
+		}
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			// sequence numbers count from the start of this call
+			if t.answer(first+int(res.Seq), res.Last) {
+				onResponse(res)
+			}
+		}
+	})
+	return t.retried, err
+}
+
+// runBidiStream sends names on a bidirectional stream, pausing interval
+// between new names. If the server goes away it resumes the session: the
+// server replays responses that were lost and the client sends the names the
+// server never got.
+func runBidiStream(ctx context.Context, client pb.OrderServiceClient, names []string, interval time.Duration, onResponse func(*pb.OrderResponse)) (retried []string, err error) {
+	t := newTracker(names)
+	var token string
+	err = resume(ctx, func(ctx context.Context) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx,
+				sessionTokenKey, token, sessionAckKey, strconv.Itoa(t.answered()))
+		}
+		stream, err := client.GetOrderBidirectionalStreaming(ctx)
+		if err != nil {
+			return err
+		}
+		header, err := stream.Header()
+		if err != nil {
+			return err
+		}
+		if header == nil {
+			// the call failed before it began, Recv says why
+			_, err := stream.Recv()
+			return err
+		}
+		var processed int
+		if v := header.Get(sessionTokenKey); len(v) > 0 {
+			token = v[0]
+		}
+		if v := header.Get(sessionSeqKey); len(v) > 0 {
+			processed, _ = strconv.Atoi(v[0])
+		}
+		first, rest := t.restart(processed)
+
+		go func() {
+			for i, name := range rest {
+				seq := first + i + 1
+				if t.markSent(seq-1) && seq > 1 && interval > 0 {
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(interval):
+					}
+				}
+				req := &pb.OrderRequest{Name: name, Seq: uint64(seq), Ack: uint64(t.answered())}
+				if err := stream.Send(req); err != nil {
+					// Recv reports why the stream broke
+					return
+				}
+			}
+			stream.CloseSend()
+		}()
+
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				// the server saw CloseSend, so it answered every name
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if t.answer(int(res.Seq), res.Last) {
+				onResponse(res)
+			}
+		}
+	})
+	return t.retried, err
+}
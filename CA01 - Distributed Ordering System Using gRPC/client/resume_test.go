@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// flakyServer answers every name once and breaks the first stream of each
+// kind with Unavailable after failAfter responses
+type flakyServer struct {
+	pb.OrderServiceServer
+	failAfter int
+
+	mtx      sync.Mutex
+	attempts int
+	received [][]string
+}
+
+func (s *flakyServer) attempt(names []string) (first bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.attempts++
+	s.received = append(s.received, names)
+	return s.attempts == 1
+}
+
+func (s *flakyServer) GetOrderServerStreaming(req *pb.NamesList, stream pb.OrderService_GetOrderServerStreamingServer) error {
+	first := s.attempt(req.Names)
+	for i, name := range req.Names {
+		if first && i == s.failAfter {
+			return status.Error(codes.Unavailable, "going away")
+		}
+		if err := stream.Send(found(name, uint64(i+1))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *flakyServer) GetOrderBidirectionalStreaming(stream pb.OrderService_GetOrderBidirectionalStreamingServer) error {
+	first := s.attempt(nil)
+	// like the real server, answer with the session header at once
+	if err := stream.SendHeader(metadata.Pairs(sessionSeqKey, "0")); err != nil {
+		return err
+	}
+	for i := 0; ; i++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if first && i == s.failAfter {
+			return status.Error(codes.Unavailable, "going away")
+		}
+		if err := stream.Send(found(req.Name, req.Seq)); err != nil {
+			return err
+		}
+	}
+}
+
+// found is the single, exact match for name
+func found(name string, seq uint64) *pb.OrderResponse {
+	return &pb.OrderResponse{
+		Query:  name,
+		Result: &pb.OrderResponse_Found{Found: &pb.ItemMatch{ItemName: name, MatchKind: pb.MatchKind_EXACT, Score: 1}},
+		Seq:    seq,
+		Last:   true,
+	}
+}
+
+func dialFlaky(t *testing.T, srv pb.OrderServiceServer) pb.OrderServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pb.RegisterOrderServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultServiceConfig(serviceConfig(maxRetries)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewOrderServiceClient(conn)
+}
+
+func TestTrackerSkipsRepeatedResponses(t *testing.T) {
+	tr := newTracker([]string{"apple", "kiwi", "kiwi", "pear"})
+	for i := 0; i < 4; i++ {
+		tr.markSent(i)
+	}
+	tr.answer(1, false)
+	tr.answer(1, true)
+	// the stream breaks after the first of kiwi's two matches
+	tr.answer(2, false)
+
+	first, rest := tr.restart(0)
+	if first != 1 || !reflect.DeepEqual(rest, []string{"kiwi", "kiwi", "pear"}) {
+		t.Errorf("restarted at %d with %v", first, rest)
+	}
+	if !reflect.DeepEqual(tr.retried, rest) {
+		t.Errorf("retried %v, want %v", tr.retried, rest)
+	}
+	// the new stream repeats kiwi's first match
+	for _, step := range []struct {
+		seq        int
+		last, want bool
+	}{{2, false, false}, {2, true, true}, {3, true, true}, {1, true, false}} {
+		if got := tr.answer(step.seq, step.last); got != step.want {
+			t.Errorf("answer(%d, %v) = %v, want %v", step.seq, step.last, got, step.want)
+		}
+	}
+	if tr.answered() != 3 {
+		t.Errorf("%d names answered, want 3", tr.answered())
+	}
+	if tr.markSent(3) {
+		t.Error("pear counted as sent for the first time twice")
+	}
+}
+
+func TestStreamsResumeAfterUnavailable(t *testing.T) {
+	names := []string{"apple", "kiwi", "pear", "mango"}
+	run := map[string]func(context.Context, pb.OrderServiceClient, func(*pb.OrderResponse)) ([]string, error){
+		"server streaming": func(ctx context.Context, c pb.OrderServiceClient, on func(*pb.OrderResponse)) ([]string, error) {
+			return runServerStream(ctx, c, names, on)
+		},
+		"bidirectional": func(ctx context.Context, c pb.OrderServiceClient, on func(*pb.OrderResponse)) ([]string, error) {
+			return runBidiStream(ctx, c, names, 0, on)
+		},
+	}
+	for kind, call := range run {
+		srv := &flakyServer{failAfter: 2}
+		client := dialFlaky(t, srv)
+		var answered []string
+		retried, err := call(context.Background(), client, func(res *pb.OrderResponse) {
+			answered = append(answered, res.Query)
+		})
+		if err != nil {
+			t.Fatalf("%v: %v", kind, err)
+		}
+		if !reflect.DeepEqual(answered, names) {
+			t.Errorf("%v: answered %v, want %v", kind, answered, names)
+		}
+		// apple and kiwi were answered before the break and are not sent again
+		for _, name := range retried {
+			if name == "apple" || name == "kiwi" {
+				t.Errorf("%v: answered name %q retried (%v)", kind, name, retried)
+			}
+		}
+		if len(retried) == 0 || retried[0] != "pear" {
+			t.Errorf("%v: retried %v, want pear first", kind, retried)
+		}
+		if srv.attempts != 2 {
+			t.Errorf("%v: %d attempts, want 2", kind, srv.attempts)
+		}
+	}
+}
+
+func TestStreamsGiveUpOnOtherErrors(t *testing.T) {
+	srv := &flakyServer{failAfter: -1}
+	client := dialFlaky(t, srv)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := runServerStream(ctx, client, []string{"apple"}, func(*pb.OrderResponse) {}); status.Code(err) != codes.Canceled {
+		t.Errorf("cancelled call returned %v", err)
+	}
+}
+
+// downServer refuses every server streaming call as if it were restarting
+type downServer struct {
+	flakyServer
+}
+
+func (s *downServer) GetOrderServerStreaming(req *pb.NamesList, stream pb.OrderService_GetOrderServerStreamingServer) error {
+	s.attempt(req.Names)
+	return status.Error(codes.Unavailable, "restarting")
+}
+
+func TestStreamRetriesAreNotMultiplied(t *testing.T) {
+	retries := maxRetries
+	maxRetries = 2
+	defer func() { maxRetries = retries }()
+	srv := &downServer{}
+	retried, err := runServerStream(context.Background(), dialFlaky(t, srv), []string{"apple"}, func(*pb.OrderResponse) {})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("got %v, want Unavailable", err)
+	}
+	// every attempt is one of resume's, so each shows in the report
+	if srv.attempts != maxRetries+1 || len(retried) != maxRetries {
+		t.Errorf("%d attempts and %v retried, want %d attempts", srv.attempts, retried, maxRetries+1)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	initialBackoff = 250 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+// maxRetries is how often a failed call is tried again, set from the configuration
+var maxRetries = 4
+
+// serviceConfig retries the calls that are safe to repeat when the server is
+// unavailable. PlaceOrder, CancelOrder and the catalog changes are left out:
+// a retry after a lost response would apply them twice. So are the streams,
+// which resume retries itself, reporting the names it sends again.
+func serviceConfig(retries int) string {
+	if retries <= 0 {
+		return `{}`
+	}
+	// gRPC caps attempts at 5 whatever the config says
+	attempts := retries + 1
+	if attempts > 5 {
+		attempts = 5
+	}
+	return fmt.Sprintf(`{
+	"methodConfig": [{
+		"name": [
+			{"service": %[1]q, "method": "GetOrder"},
+			{"service": %[2]q, "method": "ListItems"}
+		],
+		"retryPolicy": {
+			"maxAttempts": %[3]d,
+			"initialBackoff": "%.2[4]fs",
+			"maxBackoff": "%.2[5]fs",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}]
+}`, pb.OrderService_ServiceDesc.ServiceName, pb.CatalogAdminService_ServiceDesc.ServiceName,
+		attempts, initialBackoff.Seconds(), maxBackoff.Seconds())
+}
+
+// retryable reports whether a stream that failed with err is worth opening
+// again: only a lost or restarting server is, not a rejected call
+func retryable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+// backoff waits before retry number attempt (from 0), doubling each time up
+// to maxBackoff with 20% jitter so clients do not reconnect in lockstep. It
+// returns false if ctx ends first.
+func backoff(ctx context.Context, attempt int) bool {
+	delay := initialBackoff << uint(attempt)
+	if delay > maxBackoff || delay <= 0 {
+		delay = maxBackoff
+	}
+	delay = time.Duration(float64(delay) * (0.8 + 0.4*rand.Float64()))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -1,30 +1,21 @@
-package main 
+package main
 
 import (
-	"context"
-	"io"
-	"log"
-
+	"github.com/m-hariri/basic-go-grpc/logging"
 	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/status"
 )
 
 func callGetOrderServerStream(client pb.OrderServiceClient, orders *pb.NamesList) {
-	log.Printf("Server streaming started")
-	stream, err := client.GetOrderServerStreaming(context.Background(), orders) 
+	ctx, done := startOperation("search", streamTimeout)
+	logging.For(ctx).Debugf("Server streaming started")
+	retried, err := runServerStream(ctx, client, orders.Names, printResponse)
+	done(err)
+	reportRetried(retried)
 	if err != nil {
-		log.Fatalf("Could not send orders: %v", err)
-	}
-
-	for {
-		message, err := stream.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			log.Fatalf("Error while streaming %v", err)
-		}
-		log.Println(message)
+		logging.For(ctx).Infof("Error while streaming")
+		printStatus(status.Convert(err))
+		return
 	}
-
-	log.Printf("Server streaming finished")
+	logging.For(ctx).Debugf("Server streaming finished")
 }
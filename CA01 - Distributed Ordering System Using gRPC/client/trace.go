@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/tracing"
+)
+
+// startOperation starts the trace of one user command, bounded by timeout
+// unless it is 0 and the calls get their own. The calls it makes, retries and
+// reconnects included, share its trace ID, which the lines logged for ctx
+// carry too. done ends the trace, failed if its error is not nil.
+func startOperation(name string, timeout time.Duration) (ctx context.Context, done func(error)) {
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	ctx, span := tracing.Start(ctx, name, tracing.Internal)
+	return ctx, func(err error) {
+		cancel()
+		span.End(err)
+	}
+}
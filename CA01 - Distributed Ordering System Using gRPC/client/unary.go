@@ -0,0 +1,57 @@
+package main
+
+import (
+	"log"
+
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/status"
+)
+
+func callPlaceOrder(client pb.OrderServiceClient, itemName string, quantity int32) {
+	ctx, done := startOperation("place order", callTimeout)
+	order, err := client.PlaceOrder(ctx, &pb.PlaceOrderRequest{ItemName: itemName, Quantity: quantity})
+	done(err)
+	if err != nil {
+		logging.For(ctx).Infof("Could not place order")
+		printStatus(status.Convert(err))
+		return
+	}
+	log.Println(order)
+}
+
+func callGetOrder(client pb.OrderServiceClient, orderID string) {
+	ctx, done := startOperation("get order", callTimeout)
+	order, err := client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: orderID})
+	done(err)
+	if err != nil {
+		logging.For(ctx).Infof("Could not get order")
+		printStatus(status.Convert(err))
+		return
+	}
+	log.Println(order)
+}
+
+func callCancelOrder(client pb.OrderServiceClient, orderID string) {
+	ctx, done := startOperation("cancel order", callTimeout)
+	order, err := client.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: orderID})
+	done(err)
+	if err != nil {
+		logging.For(ctx).Infof("Could not cancel order")
+		printStatus(status.Convert(err))
+		return
+	}
+	log.Println(order)
+}
+
+func callFulfillOrder(client pb.OrderServiceClient, orderID string) {
+	ctx, done := startOperation("fulfill order", callTimeout)
+	order, err := client.FulfillOrder(ctx, &pb.FulfillOrderRequest{OrderId: orderID})
+	done(err)
+	if err != nil {
+		logging.For(ctx).Infof("Could not fulfill order")
+		printStatus(status.Convert(err))
+		return
+	}
+	log.Println(order)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/status"
+)
+
+// callUploadOrders streams the order lines of a CSV file to the server and
+// prints the summary it answers with
+func callUploadOrders(client pb.OrderServiceClient, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Printf("Could not open %v: %v", path, err)
+		return
+	}
+	lines, err := readOrderLines(f)
+	f.Close()
+	if err != nil {
+		log.Printf("Could not read %v, nothing ordered: %v", path, err)
+		return
+	}
+
+	ctx, done := startOperation("upload", streamTimeout)
+	stream, err := client.UploadOrders(ctx)
+	if err != nil {
+		done(err)
+		logging.For(ctx).Infof("Could not start upload")
+		printStatus(status.Convert(err))
+		return
+	}
+	// io.EOF from Send means the server ended the call, CloseAndRecv says why
+	sent, _ := sendOrderLines(lines, stream.Send)
+	summary, err := stream.CloseAndRecv()
+	done(err)
+	if err != nil {
+		logging.For(ctx).Infof("Upload failed after %d lines", sent)
+		printStatus(status.Convert(err))
+		return
+	}
+	printSummary(summary)
+}
+
+// readOrderLines parses every CSV record "item name,quantity[,reference]"
+// before anything is sent: the server orders each line as it arrives, so a
+// bad row found halfway through would leave the rows above it ordered. A
+// first row whose quantity is not a number is the header. Without a
+// reference the row number is used, so rejected lines can be found in the file.
+func readOrderLines(r io.Reader) ([]*pb.UploadOrderLine, error) {
+	records := csv.NewReader(r)
+	records.FieldsPerRecord = -1
+	records.TrimLeadingSpace = true
+	var lines []*pb.UploadOrderLine
+	for row := 1; ; row++ {
+		record, err := records.Read()
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("row %d: want item name, quantity and an optional reference, got %d fields", row, len(record))
+		}
+		quantity, err := strconv.ParseInt(strings.TrimSpace(record[1]), 10, 32)
+		if err != nil && row == 1 {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: quantity %q is not a number", row, record[1])
+		}
+		line := &pb.UploadOrderLine{
+			ItemName:  strings.TrimSpace(record[0]),
+			Quantity:  int32(quantity),
+			Reference: "row " + strconv.Itoa(row),
+		}
+		if len(record) == 3 {
+			line.Reference = record[2]
+		}
+		lines = append(lines, line)
+	}
+}
+
+// sendOrderLines sends lines until one fails, returning how many went
+func sendOrderLines(lines []*pb.UploadOrderLine, send func(*pb.UploadOrderLine) error) (int, error) {
+	for i, line := range lines {
+		if err := send(line); err != nil {
+			return i, err
+		}
+		logging.Debugf("Sent %d x %v (%v)", line.Quantity, line.ItemName, line.Reference)
+	}
+	return len(lines), nil
+}
+
+func printSummary(summary *pb.UploadSummary) {
+	log.Printf("Upload of %d lines: %d accepted for %d units, %d rejected",
+		summary.LinesReceived, summary.LinesAccepted, summary.UnitsOrdered, summary.LinesRejected)
+	for _, line := range summary.Accepted {
+		log.Printf("  line %d (%v): order %v, %d x %v", line.Line, line.Reference, line.Order.OrderId, line.Order.Quantity, line.Order.ItemName)
+	}
+	for _, line := range summary.Rejected {
+		log.Printf("  line %d (%v) rejected", line.Line, line.Reference)
+		printStatus(status.FromProto(line.Error))
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+)
+
+func TestReadOrderLines(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+		want []*pb.UploadOrderLine
+		err  bool
+	}{
+		{"header and references", "item_name,quantity,reference\napple,3,PO-1\nred apple, 2,PO-2\n", []*pb.UploadOrderLine{
+			{ItemName: "apple", Quantity: 3, Reference: "PO-1"},
+			{ItemName: "red apple", Quantity: 2, Reference: "PO-2"},
+		}, false},
+		{"row numbers", "kiwi,1\n\"pear\",4\n", []*pb.UploadOrderLine{
+			{ItemName: "kiwi", Quantity: 1, Reference: "row 1"},
+			{ItemName: "pear", Quantity: 4, Reference: "row 2"},
+		}, false},
+		{"other header names", "product,qty\nkiwi,1\n", []*pb.UploadOrderLine{
+			{ItemName: "kiwi", Quantity: 1, Reference: "row 2"},
+		}, false},
+		// the rows above a bad one are not sent either
+		{"bad quantity", "kiwi,1\npear,four\n", nil, true},
+		{"missing quantity", "kiwi\n", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := readOrderLines(strings.NewReader(tt.csv))
+		if (err != nil) != tt.err {
+			t.Errorf("%v: error %v, want error %v", tt.name, err, tt.err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: read lines %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type Client struct {
+	Target   string `yaml:"target"`
+	LogLevel string `yaml:"log_level"`
+	// LogFormat is plain, text or json
+	LogFormat string `yaml:"log_format"`
+	// TraceFile receives a line of OTLP JSON for every span, empty for none
+	TraceFile string `yaml:"trace_file"`
+	// Timeout bounds each unary call
+	Timeout time.Duration `yaml:"timeout"`
+	// StreamTimeout bounds each streaming call from start to end
+	StreamTimeout time.Duration `yaml:"stream_timeout"`
+	// SendInterval pauses between the names sent on a bidirectional stream
+	SendInterval time.Duration `yaml:"send_interval"`
+	// Retries is how often an idempotent call or a broken stream is tried again
+	Retries int    `yaml:"retries"`
+	Token   string `yaml:"token"` // API key or JWT
+
+	TLS ClientTLS `yaml:"tls"`
+
+	// Output formats the results of a command: text, table or json
+	Output string `yaml:"output"`
+	// Input is read for a command given no arguments; empty or "-" is stdin
+	Input string `yaml:"-"`
+	// History is where the interactive client keeps its command history,
+	// empty for none
+	History string `yaml:"history"`
+	// Args are the command and its arguments; without them the client runs
+	// interactively
+	Args []string `yaml:"-"`
+
+	PrintConfig bool `yaml:"-"`
+}
+
+type ClientTLS struct {
+	Enabled    bool   `yaml:"enabled"` // implied by any of the other fields
+	CA         string `yaml:"ca"`
+	Cert       string `yaml:"cert"`
+	Key        string `yaml:"key"`
+	ServerName string `yaml:"server_name"`
+}
+
+func (t ClientTLS) On() bool {
+	return t.Enabled || t.CA != "" || t.Cert != "" || t.Key != "" || t.ServerName != ""
+}
+
+func DefaultClient() Client {
+	return Client{
+		Target:        "localhost:8080",
+		LogLevel:      "info",
+		LogFormat:     "plain",
+		Timeout:       5 * time.Second,
+		StreamTimeout: time.Minute,
+		SendInterval:  2 * time.Second,
+		Retries:       4,
+		Output:        "text",
+		History:       defaultHistory(),
+	}
+}
+
+func defaultHistory() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".order_client_history")
+}
+
+// LoadClient reads the client configuration from args (without the program
+// name), the environment and the config file they point to
+func LoadClient(args []string, lookupEnv LookupEnv) (*Client, error) {
+	cfg := DefaultClient()
+	def := DefaultClient()
+	fs := flag.NewFlagSet("client", flag.ContinueOnError)
+	fs.StringVar(&cfg.Target, "target", def.Target, "server address, host:port or a gRPC target URI")
+	fs.StringVar(&cfg.LogLevel, "log-level", def.LogLevel, "debug, info, warn or error")
+	fs.StringVar(&cfg.LogFormat, "log-format", def.LogFormat, "plain, text (key=value) or json log lines")
+	fs.StringVar(&cfg.TraceFile, "trace-file", def.TraceFile, "file to append the spans of every command to, as OTLP JSON lines; empty for none")
+	fs.DurationVar(&cfg.Timeout, "timeout", def.Timeout, "deadline of each unary call")
+	fs.DurationVar(&cfg.StreamTimeout, "stream-timeout", def.StreamTimeout, "deadline of each streaming call")
+	fs.DurationVar(&cfg.SendInterval, "send-interval", def.SendInterval, "pause between names sent on a bidirectional stream, 0 for none")
+	fs.IntVar(&cfg.Retries, "retries", def.Retries, "retries of idempotent calls and reconnects of broken streams, 0 for none")
+	fs.StringVar(&cfg.Token, "token", def.Token, "API key or JWT sent as a bearer token on every call")
+	fs.BoolVar(&cfg.TLS.Enabled, "tls", def.TLS.Enabled, "connect over TLS; implied by the other -tls flags")
+	fs.StringVar(&cfg.TLS.CA, "tls-ca", def.TLS.CA, "PEM CA bundle to verify the server with instead of the system roots")
+	fs.StringVar(&cfg.TLS.Cert, "tls-cert", def.TLS.Cert, "PEM client certificate for servers requiring mutual TLS")
+	fs.StringVar(&cfg.TLS.Key, "tls-key", def.TLS.Key, "PEM private key of -tls-cert")
+	fs.StringVar(&cfg.TLS.ServerName, "tls-server-name", def.TLS.ServerName, "name to verify the server certificate against, if not the target host")
+	fs.StringVar(&cfg.Output, "output", def.Output, "format of command results: text, table or json (one object per line)")
+	fs.StringVar(&cfg.Input, "input", def.Input, "file a command reads its arguments from when given none, - for stdin")
+	fs.StringVar(&cfg.History, "history", def.History, "file the interactive client keeps its command history in, empty for none")
+	fs.BoolVar(&cfg.PrintConfig, "print-config", false, "print the effective configuration and exit")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: client [flags] [command [arguments]]\n"+
+			"commands: search, stream, order, cancel, fulfill, catalog; run \"client help\" for details.\n"+
+			"Without a command the client runs interactively.\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+
+	args, err := load(fs, args, lookupEnv, &cfg, func() { cfg = DefaultClient() })
+	if err != nil {
+		return nil, err
+	}
+	cfg.Args = args
+	return &cfg, cfg.Validate()
+}
+
+func (c *Client) Validate() error {
+	var p problems
+	if !strings.Contains(c.Target, "://") && !strings.HasPrefix(c.Target, "unix:") {
+		p.checkAddress("target", c.Target, true)
+	}
+	p.checkLogLevel(c.LogLevel)
+	p.checkLogFormat(c.LogFormat)
+	if c.Timeout <= 0 {
+		p.addf("timeout: must be positive, got %v", c.Timeout)
+	}
+	if c.StreamTimeout <= 0 {
+		p.addf("stream_timeout: must be positive, got %v", c.StreamTimeout)
+	}
+	if c.SendInterval < 0 {
+		p.addf("send_interval: must not be negative, got %v", c.SendInterval)
+	}
+	switch c.Output {
+	case "text", "table", "json":
+	default:
+		p.addf("output: unknown format %q, want text, table or json", c.Output)
+	}
+	if c.Retries < 0 {
+		p.addf("retries: must not be negative, got %d", c.Retries)
+	}
+	if (c.TLS.Cert == "") != (c.TLS.Key == "") {
+		p.addf("tls: cert and key must be given together")
+	}
+	return p.err()
+}
+
+// Redacted is c with its secrets masked, for printing
+func (c Client) Redacted() Client {
+	if c.Token != "" {
+		c.Token = "REDACTED"
+	}
+	return c
+}
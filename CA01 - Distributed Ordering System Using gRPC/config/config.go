@@ -0,0 +1,151 @@
+// Package config loads the server and client settings. Each setting can come
+// from, in increasing priority, its default, a YAML config file, an ORDER_*
+// environment variable and a command line flag.
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/m-hariri/basic-go-grpc/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix starts every environment variable: flag -tls-cert is read from
+// ORDER_TLS_CERT, and ORDER_CONFIG names the config file
+const EnvPrefix = "ORDER_"
+
+// LookupEnv is os.LookupEnv, swappable for tests
+type LookupEnv func(key string) (string, bool)
+
+func EnvName(flagName string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// load fills cfg, whose fields the flags of fs are bound to. Flags are parsed
+// first to find the config file and remember what was given explicitly; then
+// cfg starts over from reset, the file, the environment and those flags.
+// Flags may come before, between or after the other arguments, which are
+// returned; "--" ends the flags.
+func load(fs *flag.FlagSet, args []string, lookupEnv LookupEnv, cfg interface{}, reset func()) ([]string, error) {
+	configFile := fs.String("config", "", "YAML config file; environment variables and flags override it (env "+EnvName("config")+")")
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		if parsed := len(args) - fs.NArg(); parsed > 0 && args[parsed-1] == "--" {
+			positional = append(positional, fs.Args()...)
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	explicit := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = f.Value.String()
+	})
+
+	reset()
+	path := *configFile
+	if env, ok := lookupEnv(EnvName("config")); ok && path == "" {
+		path = env
+	}
+	if path != "" {
+		if err := readFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	var errs []string
+	fs.VisitAll(func(f *flag.Flag) {
+		if f.Name == "config" {
+			return
+		}
+		if v, ok := lookupEnv(EnvName(f.Name)); ok {
+			if err := f.Value.Set(v); err != nil {
+				errs = append(errs, fmt.Sprintf("%v: %v", EnvName(f.Name), err))
+			}
+		}
+	})
+	for name, v := range explicit {
+		fs.Set(name, v)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid environment: %v", strings.Join(errs, "; "))
+	}
+	return positional, nil
+}
+
+// readFile rejects unknown keys so a misspelt setting does not go unnoticed
+func readFile(path string, cfg interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && err != io.EOF {
+		return fmt.Errorf("parse config %v: %w", path, err)
+	}
+	return nil
+}
+
+// Print writes cfg in the config file format
+func Print(w io.Writer, cfg interface{}) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// problems collects validation failures so they are all reported at once
+type problems []string
+
+func (p *problems) addf(format string, args ...interface{}) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+func (p problems) err() error {
+	if len(p) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration:\n  %v", strings.Join(p, "\n  "))
+}
+
+func (p *problems) checkAddress(name, addr string, needHost bool) {
+	host, port, err := net.SplitHostPort(addr)
+	switch {
+	case err != nil:
+		p.addf("%v %q: %v", name, addr, err)
+	case port == "":
+		p.addf("%v %q: missing port", name, addr)
+	case needHost && host == "":
+		p.addf("%v %q: missing host", name, addr)
+	}
+}
+
+func (p *problems) checkLogLevel(level string) {
+	if _, err := logging.ParseLevel(level); err != nil {
+		p.addf("log_level: %v", err)
+	}
+}
+
+func (p *problems) checkLogFormat(format string) {
+	for _, f := range logging.Formats {
+		if format == f {
+			return
+		}
+	}
+	p.addf("log_format: unknown format %q, want %v", format, strings.Join(logging.Formats, ", "))
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func env(vars map[string]string) LookupEnv {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestServerPrecedence(t *testing.T) {
+	path := writeConfig(t, `
+listen: ":9000"
+log_level: debug
+max_results: 3
+catalog: {kind: file, path: from-file.yaml}
+`)
+	cfg, err := LoadServer(
+		[]string{"-config", path, "-catalog-path", "from-flag.yaml"},
+		env(map[string]string{"ORDER_LISTEN": ":9100", "ORDER_CATALOG_PATH": "from-env.yaml"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := DefaultServer()
+	want.Listen = ":9100"                // env over file
+	want.LogLevel = "debug"              // file over default
+	want.MaxResults = 3                  // file over default
+	want.Catalog.Kind = "file"           // file over default
+	want.Catalog.Path = "from-flag.yaml" // flag over env and file
+	if *cfg != want {
+		t.Errorf("got %+v\nwant %+v", *cfg, want)
+	}
+}
+
+func TestClientConfigFromEnv(t *testing.T) {
+	path := writeConfig(t, "target: orders.internal:443\ntls: {ca: ca.pem}\n")
+	cfg, err := LoadClient(nil, env(map[string]string{"ORDER_CONFIG": path, "ORDER_TIMEOUT": "750ms", "ORDER_TOKEN": "secret"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Target != "orders.internal:443" || cfg.Timeout != 750*time.Millisecond || cfg.TLS.CA != "ca.pem" || !cfg.TLS.On() {
+		t.Errorf("unexpected config %+v", *cfg)
+	}
+
+	var out bytes.Buffer
+	if err := Print(&out, cfg.Redacted()); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "secret") || !strings.Contains(out.String(), "target: orders.internal:443") {
+		t.Errorf("printed config:\n%v", out.String())
+	}
+}
+
+func TestClientCommandArgs(t *testing.T) {
+	tests := []struct {
+		args   []string
+		want   []string
+		output string
+	}{
+		{[]string{"search", "red apple", "kiwi"}, []string{"search", "red apple", "kiwi"}, "text"},
+		{[]string{"-output", "json", "search", "kiwi"}, []string{"search", "kiwi"}, "json"},
+		{[]string{"search", "-output", "table", "kiwi", "--output=json"}, []string{"search", "kiwi"}, "json"},
+		{[]string{"cancel", "--", "-not-a-flag"}, []string{"cancel", "-not-a-flag"}, "text"},
+	}
+	for _, tt := range tests {
+		cfg, err := LoadClient(tt.args, env(nil))
+		if err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		if strings.Join(cfg.Args, "|") != strings.Join(tt.want, "|") || cfg.Output != tt.output {
+			t.Errorf("%v: args %q output %v, want %q %v", tt.args, cfg.Args, cfg.Output, tt.want, tt.output)
+		}
+	}
+}
+
+func TestValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		file string
+		want []string // substrings of the error, nil for success
+	}{
+		{name: "defaults"},
+		{name: "several problems at once", args: []string{"-listen", "nowhere", "-catalog", "kv", "-log-level", "loud"},
+			want: []string{"listen", "catalog.path", "log_level"}},
+		{name: "unknown log format", env: map[string]string{"ORDER_LOG_FORMAT": "xml"}, want: []string{"log_format"}},
+		{name: "tls key without cert", args: []string{"-tls-key", "k.pem"}, want: []string{"cert and key"}},
+		{name: "gateway without client certificate", args: []string{"-tls-cert", "c.pem", "-tls-key", "k.pem", "-tls-client-ca", "ca.pem", "-gateway-listen", "localhost:8081"},
+			want: []string{"gateway.tls"}},
+		{name: "unknown backend", env: map[string]string{"ORDER_CATALOG": "sql"}, want: []string{"unknown backend"}},
+		{name: "bad env value", env: map[string]string{"ORDER_MAX_RESULTS": "many"}, want: []string{"ORDER_MAX_RESULTS"}},
+		{name: "misspelt file key", file: "max_result: 5\n", want: []string{"max_result"}},
+		{name: "stray argument", args: []string{"extra"}, want: []string{"unexpected arguments"}},
+	}
+	for _, tt := range tests {
+		args := tt.args
+		if tt.file != "" {
+			args = append([]string{"-config", writeConfig(t, tt.file)}, args...)
+		}
+		_, err := LoadServer(args, env(tt.env))
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("%v: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%v: no error", tt.name)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("%v: error %q does not mention %q", tt.name, err, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"time"
+)
+
+type Server struct {
+	Listen     string `yaml:"listen"`
+	LogLevel   string `yaml:"log_level"`
+	LogFormat  string `yaml:"log_format"`  // plain, text or json
+	MaxResults int    `yaml:"max_results"` // matches per requested name, 0 for no limit
+	// ConnectionTimeout bounds the connection handshake, TLS included
+	ConnectionTimeout time.Duration `yaml:"connection_timeout"`
+	// DrainTimeout is how long in-flight calls may run on after SIGINT or
+	// SIGTERM before they are cut off
+	DrainTimeout time.Duration `yaml:"drain_timeout"`
+	// HealthInterval is how often catalog readiness is probed for the
+	// grpc.health.v1 service
+	HealthInterval time.Duration `yaml:"health_interval"`
+	// StreamPacing pauses server streaming calls between names, 0 for none
+	StreamPacing time.Duration `yaml:"stream_pacing"`
+	// SessionTTL is how long a bidirectional stream's session may be resumed
+	// after its stream broke
+	SessionTTL time.Duration `yaml:"session_ttl"`
+	// MetricsListen is the address of the HTTP listener serving Prometheus
+	// metrics on /metrics, empty for none
+	MetricsListen string `yaml:"metrics_listen"`
+	// TraceFile receives a line of OTLP JSON for every span, empty for none
+	TraceFile string `yaml:"trace_file"`
+	// Reflection registers the server reflection service for tools like grpcurl
+	Reflection bool `yaml:"reflection"`
+
+	Catalog CatalogSource `yaml:"catalog"`
+	TLS     ServerTLS     `yaml:"tls"`
+	Auth    Auth          `yaml:"auth"`
+	Gateway Gateway       `yaml:"gateway"`
+
+	PrintConfig bool `yaml:"-"`
+}
+
+type CatalogSource struct {
+	Kind string `yaml:"kind"` // memory, file or kv
+	Path string `yaml:"path"`
+}
+
+// OrdersPath is where the orders are journaled next to a file or kv catalog,
+// so stock reserved by an order outlives a restart along with the order; the
+// memory catalog forgets both
+func (c CatalogSource) OrdersPath() string {
+	if c.Kind == "memory" || c.Path == "" {
+		return ""
+	}
+	return c.Path + ".orders"
+}
+
+type ServerTLS struct {
+	Cert     string `yaml:"cert"`
+	Key      string `yaml:"key"`
+	ClientCA string `yaml:"client_ca"` // enables mutual TLS
+}
+
+func (t ServerTLS) Enabled() bool {
+	return t.Cert != "" || t.Key != "" || t.ClientCA != ""
+}
+
+// Gateway serves OrderService as REST/JSON over HTTP. It calls the gRPC port
+// like any client, so with TLS it needs what a client needs.
+type Gateway struct {
+	Listen string    `yaml:"listen"` // empty for no gateway
+	TLS    ClientTLS `yaml:"tls"`
+}
+
+type Auth struct {
+	Keys   string `yaml:"keys"`
+	JWTKey string `yaml:"jwt_key"`
+}
+
+func DefaultServer() Server {
+	return Server{
+		Listen:            ":8080",
+		LogLevel:          "info",
+		LogFormat:         "plain",
+		MaxResults:        10,
+		ConnectionTimeout: 20 * time.Second,
+		DrainTimeout:      15 * time.Second,
+		HealthInterval:    5 * time.Second,
+		StreamPacing:      2 * time.Second,
+		SessionTTL:        5 * time.Minute,
+		Catalog:           CatalogSource{Kind: "memory"},
+	}
+}
+
+// LoadServer reads the server configuration from args (without the program
+// name), the environment and the config file they point to
+func LoadServer(args []string, lookupEnv LookupEnv) (*Server, error) {
+	cfg := DefaultServer()
+	def := DefaultServer()
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	fs.StringVar(&cfg.Listen, "listen", def.Listen, "address to serve on")
+	fs.StringVar(&cfg.LogLevel, "log-level", def.LogLevel, "debug, info, warn or error")
+	fs.StringVar(&cfg.LogFormat, "log-format", def.LogFormat, "plain, text (key=value) or json log lines")
+	fs.IntVar(&cfg.MaxResults, "max-results", def.MaxResults, "most matches returned per requested name, 0 for no limit")
+	fs.DurationVar(&cfg.ConnectionTimeout, "connection-timeout", def.ConnectionTimeout, "time allowed for a new connection's handshake")
+	fs.DurationVar(&cfg.DrainTimeout, "drain-timeout", def.DrainTimeout, "how long in-flight calls may finish after SIGINT/SIGTERM")
+	fs.DurationVar(&cfg.HealthInterval, "health-interval", def.HealthInterval, "how often catalog readiness is checked for health reporting")
+	fs.DurationVar(&cfg.StreamPacing, "stream-pacing", def.StreamPacing, "pause between the names of a server streaming call, 0 for none")
+	fs.DurationVar(&cfg.SessionTTL, "session-ttl", def.SessionTTL, "how long a broken bidirectional stream's session can be resumed")
+	fs.StringVar(&cfg.MetricsListen, "metrics-listen", def.MetricsListen, "address to serve Prometheus metrics on at /metrics, e.g. localhost:9090; empty for none")
+	fs.StringVar(&cfg.TraceFile, "trace-file", def.TraceFile, "file to append the spans of every call to, as OTLP JSON lines; empty for none")
+	fs.BoolVar(&cfg.Reflection, "reflection", def.Reflection, "serve gRPC server reflection so tools can discover the services")
+	fs.StringVar(&cfg.Catalog.Kind, "catalog", def.Catalog.Kind, "catalog backend: memory, file or kv")
+	fs.StringVar(&cfg.Catalog.Path, "catalog-path", def.Catalog.Path, "catalog file (.json/.yaml) for -catalog=file, or database file for -catalog=kv; orders are journaled to this path plus .orders")
+	fs.StringVar(&cfg.TLS.Cert, "tls-cert", def.TLS.Cert, "PEM server certificate; serves plaintext when empty")
+	fs.StringVar(&cfg.TLS.Key, "tls-key", def.TLS.Key, "PEM private key of -tls-cert")
+	fs.StringVar(&cfg.TLS.ClientCA, "tls-client-ca", def.TLS.ClientCA, "PEM CA bundle; when set, clients must present a certificate it signed (mutual TLS)")
+	fs.StringVar(&cfg.Auth.Keys, "auth-keys", def.Auth.Keys, "YAML file of static API keys with their subjects and roles")
+	fs.StringVar(&cfg.Auth.JWTKey, "auth-jwt-key", def.Auth.JWTKey, "file holding the HMAC key that signs accepted JWTs (see gentoken)")
+	fs.StringVar(&cfg.Gateway.Listen, "gateway-listen", def.Gateway.Listen, "address to serve the REST/JSON gateway and its OpenAPI document on, e.g. localhost:8081; empty for none")
+	fs.StringVar(&cfg.Gateway.TLS.CA, "gateway-tls-ca", def.Gateway.TLS.CA, "PEM CA bundle the gateway verifies the gRPC port's certificate with")
+	fs.StringVar(&cfg.Gateway.TLS.Cert, "gateway-tls-cert", def.Gateway.TLS.Cert, "PEM client certificate of the gateway, for -tls-client-ca")
+	fs.StringVar(&cfg.Gateway.TLS.Key, "gateway-tls-key", def.Gateway.TLS.Key, "PEM private key of -gateway-tls-cert")
+	fs.StringVar(&cfg.Gateway.TLS.ServerName, "gateway-tls-server-name", def.Gateway.TLS.ServerName, "name the gateway checks the gRPC port's certificate against, if not localhost")
+	fs.BoolVar(&cfg.PrintConfig, "print-config", false, "print the effective configuration and exit")
+
+	rest, err := load(fs, args, lookupEnv, &cfg, func() { cfg = DefaultServer() })
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", rest)
+	}
+	return &cfg, cfg.Validate()
+}
+
+func (c *Server) Validate() error {
+	var p problems
+	p.checkAddress("listen", c.Listen, false)
+	p.checkLogLevel(c.LogLevel)
+	p.checkLogFormat(c.LogFormat)
+	if c.MetricsListen != "" {
+		p.checkAddress("metrics_listen", c.MetricsListen, false)
+	}
+	if c.MaxResults < 0 {
+		p.addf("max_results: must not be negative, got %d", c.MaxResults)
+	}
+	if c.ConnectionTimeout <= 0 {
+		p.addf("connection_timeout: must be positive, got %v", c.ConnectionTimeout)
+	}
+	if c.DrainTimeout <= 0 {
+		p.addf("drain_timeout: must be positive, got %v", c.DrainTimeout)
+	}
+	if c.StreamPacing < 0 {
+		p.addf("stream_pacing: must not be negative, got %v", c.StreamPacing)
+	}
+	if c.SessionTTL <= 0 {
+		p.addf("session_ttl: must be positive, got %v", c.SessionTTL)
+	}
+	if c.HealthInterval <= 0 {
+		p.addf("health_interval: must be positive, got %v", c.HealthInterval)
+	}
+	switch c.Catalog.Kind {
+	case "memory":
+	case "file", "kv":
+		if c.Catalog.Path == "" {
+			p.addf("catalog.path: required for the %v catalog", c.Catalog.Kind)
+		}
+	default:
+		p.addf("catalog.kind: unknown backend %q, want memory, file or kv", c.Catalog.Kind)
+	}
+	if c.TLS.Enabled() && (c.TLS.Cert == "" || c.TLS.Key == "") {
+		p.addf("tls: cert and key are both required to serve TLS")
+	}
+	if c.Gateway.Listen != "" {
+		p.checkAddress("gateway.listen", c.Gateway.Listen, false)
+		if c.TLS.ClientCA != "" && (c.Gateway.TLS.Cert == "" || c.Gateway.TLS.Key == "") {
+			p.addf("gateway.tls: cert and key are required to call a server asking for mutual TLS")
+		}
+	}
+	return p.err()
+}
@@ -0,0 +1,36 @@
+// Command gencerts writes a local development CA together with a server and a
+// client certificate signed by it, for trying out TLS and mutual TLS:
+//
+//	go run ./gencerts -out certs
+//	go run ./server -tls-cert certs/server.pem -tls-key certs/server-key.pem -tls-client-ca certs/ca.pem
+//	go run ./client -tls-ca certs/ca.pem -tls-cert certs/client.pem -tls-key certs/client-key.pem
+package main
+
+import (
+	"flag"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/tlsutil"
+)
+
+func main() {
+	out := flag.String("out", "certs", "directory to write the PEM files to")
+	hosts := flag.String("hosts", "", "comma separated extra DNS names or IPs for the server certificate")
+	clientName := flag.String("client-name", "dev-client", "common name of the client certificate")
+	validity := flag.Duration("validity", 365*24*time.Hour, "how long the certificates stay valid")
+	flag.Parse()
+
+	var extra []string
+	for _, host := range strings.Split(*hosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			extra = append(extra, host)
+		}
+	}
+	opts := tlsutil.DevOptions{Hosts: extra, ClientName: *clientName, Validity: *validity}
+	if err := tlsutil.GenerateDev(*out, opts); err != nil {
+		log.Fatalf("Failed to generate certificates: %v", err)
+	}
+	log.Printf("Wrote dev CA, server and client certificates to %v", *out)
+}
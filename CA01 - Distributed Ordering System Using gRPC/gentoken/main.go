@@ -0,0 +1,67 @@
+// Command gentoken issues HS256 JWTs the order server accepts with
+// -auth-jwt-key, creating the signing key on first use:
+//
+//	go run ./gentoken -key jwt.key -sub alice -roles admin
+//	go run ./server -auth-jwt-key jwt.key
+//	go run ./client -token <printed token>
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/auth"
+)
+
+func main() {
+	keyFile := flag.String("key", "jwt.key", "HMAC key file; a random key is written there if it does not exist")
+	subject := flag.String("sub", "", "subject (caller name) of the token")
+	roles := flag.String("roles", "", "comma separated roles, e.g. admin")
+	ttl := flag.Duration("ttl", 24*time.Hour, "how long the token stays valid, 0 for ever")
+	flag.Parse()
+
+	if *subject == "" {
+		log.Fatalf("-sub is required")
+	}
+	if err := ensureKey(*keyFile); err != nil {
+		log.Fatalf("Failed to create key: %v", err)
+	}
+	key, err := auth.LoadJWTKey(*keyFile)
+	if err != nil {
+		log.Fatalf("Failed to load key: %v", err)
+	}
+
+	now := time.Now()
+	claims := auth.Claims{Subject: *subject, Issuer: "gentoken", IssuedAt: now.Unix()}
+	if *roles != "" {
+		claims.Roles = strings.Split(*roles, ",")
+	}
+	if *ttl > 0 {
+		claims.ExpiresAt = now.Add(*ttl).Unix()
+	}
+	token, err := auth.NewJWT(key).Sign(claims)
+	if err != nil {
+		log.Fatalf("Failed to sign token: %v", err)
+	}
+	fmt.Println(token)
+}
+
+func ensureKey(path string) error {
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	log.Printf("Writing new signing key to %v", path)
+	return os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0600)
+}
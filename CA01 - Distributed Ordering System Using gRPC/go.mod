@@ -1,15 +1,21 @@
 module github.com/m-hariri/basic-go-grpc
 
-go 1.18
+go 1.21
 
 require (
+	github.com/chzyer/readline v1.5.1
+	github.com/fsnotify/fsnotify v1.7.0
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.19.1
+	go.etcd.io/bbolt v1.3.9
+	golang.org/x/text v0.14.0
+	google.golang.org/genproto/googleapis/api v0.0.0-20240227224415-6ceb2ff114de
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240227224415-6ceb2ff114de
 	google.golang.org/grpc v1.63.2
 	google.golang.org/protobuf v1.33.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	golang.org/x/net v0.21.0 // indirect
 	golang.org/x/sys v0.17.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20240227224415-6ceb2ff114de // indirect
 )
@@ -0,0 +1,213 @@
+// Command loadtest measures how many concurrent streams the order server
+// handles before its latency degrades. For every level of -streams it keeps
+// that many server streaming calls and as many bidirectional sessions busy
+// for -duration, then reports throughput and latency percentiles per RPC:
+//
+//	go run ./server -stream-pacing 0
+//	go run ./loadtest -streams 1,10,50,100 -json before.json
+//	go run ./loadtest -streams 1,10,50,100 -compare before.json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/auth"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"github.com/m-hariri/basic-go-grpc/tlsutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// names of the RPCs as -rpcs takes them and the report shows them
+const (
+	serverStreaming = "server"
+	bidiStreaming   = "bidi"
+)
+
+func main() {
+	target := flag.String("target", "localhost:8080", "address of the order server")
+	token := flag.String("token", "", "bearer token sent with every call")
+	tlsCA := flag.String("tls-ca", "", "CA certificate to verify the server with; enables TLS")
+	tlsServerName := flag.String("tls-server-name", "", "name to verify the server certificate against, if not the target host")
+	streamLevels := flag.String("streams", "10", "comma separated numbers of concurrent streams per RPC, each run in turn")
+	conns := flag.Int("conns", 1, "connections the streams are spread over")
+	duration := flag.Duration("duration", 10*time.Second, "how long each level of -streams runs")
+	rpcs := flag.String("rpcs", "server,bidi", "RPCs to drive: server (server streaming) and bidi (bidirectional sessions)")
+	names := flag.String("names", "", "comma separated names to look up; default the catalog's item names")
+	distribution := flag.String("distribution", "uniform", "how names are picked: uniform, or zipf for a few popular names")
+	miss := flag.Float64("miss", 0, "fraction of names that match no item, 0 to 1")
+	namesPerCall := flag.Int("names-per-call", 5, "names sent on each server streaming call")
+	sessionRequests := flag.Int("session-requests", 100, "names sent on a bidirectional session before it is closed and a new one opened")
+	jsonOut := flag.String("json", "", "file to write the results to as JSON, - for stdout")
+	compare := flag.String("compare", "", "JSON results of an earlier run to compare with")
+	flag.Parse()
+
+	levels, err := parseLevels(*streamLevels)
+	if err != nil {
+		log.Fatalf("-streams: %v", err)
+	}
+	drive, err := parseRPCs(*rpcs)
+	if err != nil {
+		log.Fatalf("-rpcs: %v", err)
+	}
+	if *conns < 1 || *namesPerCall < 1 || *sessionRequests < 1 {
+		log.Fatalf("-conns, -names-per-call and -session-requests must be positive")
+	}
+	var baseline *report
+	if *compare != "" {
+		if baseline, err = readReport(*compare); err != nil {
+			log.Fatalf("Could not read %v: %v", *compare, err)
+		}
+	}
+
+	creds := insecure.NewCredentials()
+	if *tlsCA != "" {
+		tlsConfig, err := tlsutil.ClientConfig(*tlsCA, "", "", *tlsServerName)
+		if err != nil {
+			log.Fatalf("Failed to set up TLS: %v", err)
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if *token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(auth.Bearer(*token, *tlsCA != "")))
+	}
+	clients := make([]pb.OrderServiceClient, *conns)
+	var admin pb.CatalogAdminServiceClient
+	for i := range clients {
+		conn, err := grpc.Dial(*target, dialOpts...)
+		if err != nil {
+			log.Fatalf("Connection failed: %v", err)
+		}
+		defer conn.Close()
+		clients[i] = pb.NewOrderServiceClient(conn)
+		admin = pb.NewCatalogAdminServiceClient(conn)
+	}
+
+	w := &workload{distribution: *distribution, miss: *miss}
+	if *names != "" {
+		w.names = strings.Split(*names, ",")
+	} else if w.names, err = catalogNames(admin); err != nil {
+		log.Fatalf("Could not list the catalog for names to look up: %v", err)
+	}
+	if err := w.validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	rep := &report{
+		Target:          *target,
+		Started:         time.Now().UTC(),
+		Duration:        duration.String(),
+		Distribution:    *distribution,
+		Miss:            *miss,
+		NamesPerCall:    *namesPerCall,
+		SessionRequests: *sessionRequests,
+	}
+	for _, streams := range levels {
+		log.Printf("Running %d streams per RPC for %v", streams, *duration)
+		l := runLevel(clients, w, drive, streams, *duration, *namesPerCall, *sessionRequests)
+		rep.Levels = append(rep.Levels, l)
+	}
+
+	rep.print(os.Stdout)
+	if baseline != nil {
+		fmt.Println()
+		rep.compare(os.Stdout, baseline)
+	}
+	if *jsonOut != "" {
+		if err := rep.write(*jsonOut); err != nil {
+			log.Fatalf("Could not write %v: %v", *jsonOut, err)
+		}
+	}
+}
+
+func parseLevels(s string) ([]int, error) {
+	var levels []int
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%q is not a positive number of streams", field)
+		}
+		levels = append(levels, n)
+	}
+	return levels, nil
+}
+
+func parseRPCs(s string) ([]string, error) {
+	var rpcs []string
+	for _, field := range strings.Split(s, ",") {
+		switch field = strings.TrimSpace(field); field {
+		case serverStreaming, bidiStreaming:
+			rpcs = append(rpcs, field)
+		default:
+			return nil, fmt.Errorf("unknown RPC %q, want server or bidi", field)
+		}
+	}
+	return rpcs, nil
+}
+
+// catalogNames lists the names of every catalog item
+func catalogNames(admin pb.CatalogAdminServiceClient) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var names []string
+	req := &pb.ListItemsRequest{}
+	for {
+		res, err := admin.ListItems(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Items {
+			names = append(names, item.Name)
+		}
+		if res.NextPageToken == "" {
+			return names, nil
+		}
+		req.PageToken = res.NextPageToken
+	}
+}
+
+// runLevel keeps streams calls of every RPC in drive busy for d, spreading
+// them over the clients
+func runLevel(clients []pb.OrderServiceClient, w *workload, drive []string, streams int, d time.Duration, namesPerCall, sessionRequests int) level {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	recorders := make(map[string]*recorder)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for _, rpc := range drive {
+		rec := newRecorder()
+		recorders[rpc] = rec
+		for i := 0; i < streams; i++ {
+			client := clients[i%len(clients)]
+			p := w.picker(start.UnixNano() + int64(len(recorders)*streams+i))
+			wg.Add(1)
+			go func(rpc string) {
+				defer wg.Done()
+				if rpc == serverStreaming {
+					serverWorker(ctx, client, p, rec, namesPerCall)
+				} else {
+					bidiWorker(ctx, client, p, rec, sessionRequests)
+				}
+			}(rpc)
+		}
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	l := level{Streams: streams}
+	for _, rpc := range drive {
+		l.RPCs = append(l.RPCs, recorders[rpc].result(rpc, elapsed))
+	}
+	return l
+}
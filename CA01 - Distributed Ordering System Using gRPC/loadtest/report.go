@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// report is the outcome of a run, as written with -json and read by -compare
+type report struct {
+	Target          string    `json:"target"`
+	Started         time.Time `json:"started"`
+	Duration        string    `json:"duration_per_level"`
+	Distribution    string    `json:"distribution"`
+	Miss            float64   `json:"miss"`
+	NamesPerCall    int       `json:"names_per_call"`
+	SessionRequests int       `json:"session_requests"`
+	Levels          []level   `json:"levels"`
+}
+
+// level is one number of concurrent streams per RPC
+type level struct {
+	Streams int         `json:"streams"`
+	RPCs    []rpcResult `json:"rpcs"`
+}
+
+type rpcResult struct {
+	RPC string `json:"rpc"`
+	// Requests are the server streaming calls made, or the names sent on
+	// bidirectional sessions, that completed within the level
+	Requests   int            `json:"requests"`
+	Responses  int            `json:"responses"`
+	Errors     map[string]int `json:"errors,omitempty"` // by status code
+	Throughput float64        `json:"requests_per_second"`
+	Latency    latency        `json:"latency_ms"`
+}
+
+type latency struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// recorder collects the measurements of one RPC from all its streams
+type recorder struct {
+	mtx       sync.Mutex
+	latencies []time.Duration
+	responses int
+	errors    map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{errors: make(map[string]int)}
+}
+
+// record adds a request that took d; failed requests count as errors and
+// leave the latencies alone
+func (r *recorder) record(d time.Duration, responses int, err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.responses += responses
+	if err != nil {
+		r.errors[status.Code(err).String()]++
+		return
+	}
+	r.latencies = append(r.latencies, d)
+}
+
+func (r *recorder) result(rpc string, elapsed time.Duration) rpcResult {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	res := rpcResult{RPC: rpc, Requests: len(r.latencies), Responses: r.responses}
+	for code, n := range r.errors {
+		if res.Errors == nil {
+			res.Errors = make(map[string]int)
+		}
+		res.Errors[code] = n
+		res.Requests += n
+	}
+	res.Throughput = float64(len(r.latencies)) / elapsed.Seconds()
+	res.Latency = summarize(r.latencies)
+	return res
+}
+
+// summarize computes the mean, maximum and nearest-rank percentiles of
+// latencies in milliseconds; it sorts latencies
+func summarize(latencies []time.Duration) latency {
+	if len(latencies) == 0 {
+		return latency{}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	var total time.Duration
+	for _, d := range latencies {
+		total += d
+	}
+	percentile := func(p int) float64 {
+		rank := (p*len(latencies) + 99) / 100 // ceil(p% of n), 1-based
+		return ms(latencies[rank-1])
+	}
+	return latency{
+		Mean: ms(total / time.Duration(len(latencies))),
+		P50:  percentile(50),
+		P95:  percentile(95),
+		P99:  percentile(99),
+		Max:  ms(latencies[len(latencies)-1]),
+	}
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func (rep *report) print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "STREAMS\tRPC\tREQUESTS\tREQ/S\tERRORS\tP50 MS\tP95 MS\tP99 MS\tMAX MS\t")
+	for _, l := range rep.Levels {
+		for _, r := range l.RPCs {
+			fmt.Fprintf(tw, "%d\t%v\t%d\t%.1f\t%v\t%.2f\t%.2f\t%.2f\t%.2f\t\n",
+				l.Streams, r.RPC, r.Requests, r.Throughput, errorText(r.Errors), r.Latency.P50, r.Latency.P95, r.Latency.P99, r.Latency.Max)
+		}
+	}
+	tw.Flush()
+}
+
+func errorText(errors map[string]int) string {
+	if len(errors) == 0 {
+		return "0"
+	}
+	var parts []string
+	for code, n := range errors {
+		parts = append(parts, fmt.Sprintf("%d %v", n, code))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+// compare prints how throughput and latency changed against an earlier run,
+// for the levels and RPCs both runs have
+func (rep *report) compare(w io.Writer, baseline *report) {
+	before := make(map[string]rpcResult)
+	for _, l := range baseline.Levels {
+		for _, r := range l.RPCs {
+			before[fmt.Sprint(l.Streams, r.RPC)] = r
+		}
+	}
+	fmt.Fprintf(w, "Compared with the run of %v:\n", baseline.Started.Format(time.RFC3339))
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "STREAMS\tRPC\tREQ/S\tP50\tP95\tP99\t")
+	for _, l := range rep.Levels {
+		for _, r := range l.RPCs {
+			b, ok := before[fmt.Sprint(l.Streams, r.RPC)]
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(tw, "%d\t%v\t%v\t%v\t%v\t%v\t\n", l.Streams, r.RPC,
+				change(b.Throughput, r.Throughput), change(b.Latency.P50, r.Latency.P50),
+				change(b.Latency.P95, r.Latency.P95), change(b.Latency.P99, r.Latency.P99))
+		}
+	}
+	tw.Flush()
+}
+
+func change(before, after float64) string {
+	if before == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.1f%%", (after-before)/before*100)
+}
+
+// write saves the report as indented JSON to path, or stdout for -
+func (rep *report) write(path string) error {
+	data, err := json.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func readReport(path string) (*report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rep := &report{}
+	if err := json.Unmarshal(data, rep); err != nil {
+		return nil, err
+	}
+	return rep, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSummarize(t *testing.T) {
+	// 1ms to 100ms in random order
+	latencies := make([]time.Duration, 100)
+	for i, j := range rand.Perm(100) {
+		latencies[i] = time.Duration(j+1) * time.Millisecond
+	}
+	got := summarize(latencies)
+	want := latency{Mean: 50.5, P50: 50, P95: 95, P99: 99, Max: 100}
+	if got != want {
+		t.Errorf("summarize = %+v, want %+v", got, want)
+	}
+	if got := summarize([]time.Duration{time.Millisecond}); got.P99 != 1 || got.P50 != 1 {
+		t.Errorf("a single latency summarizes as %+v", got)
+	}
+}
+
+func TestRecorderCountsErrorsApart(t *testing.T) {
+	rec := newRecorder()
+	rec.record(2*time.Millisecond, 3, nil)
+	rec.record(4*time.Millisecond, 1, nil)
+	rec.record(time.Second, 0, status.Error(codes.Unavailable, "going away"))
+	rec.record(time.Second, 0, errors.New("broken"))
+	res := rec.result("bidi", 2*time.Second)
+	if res.Requests != 4 || res.Responses != 4 || res.Throughput != 1 {
+		t.Errorf("%d requests, %d responses, %v per second", res.Requests, res.Responses, res.Throughput)
+	}
+	if res.Errors["Unavailable"] != 1 || res.Errors["Unknown"] != 1 {
+		t.Errorf("errors %v", res.Errors)
+	}
+	if res.Latency.Max != 4 {
+		t.Errorf("failed requests counted in the latency: %+v", res.Latency)
+	}
+}
+
+func TestPickerDistributions(t *testing.T) {
+	names := []string{"apple", "kiwi", "pear", "mango", "grape", "banana", "cherry", "orange"}
+	const draws = 10000
+	count := func(w *workload) map[string]int {
+		counts := make(map[string]int)
+		p := w.picker(1)
+		for i := 0; i < draws; i++ {
+			name := p.next()
+			if strings.HasPrefix(name, "no such item") {
+				name = "miss"
+			}
+			counts[name]++
+		}
+		return counts
+	}
+
+	uniform := count(&workload{names: names, distribution: "uniform", miss: 0.25})
+	if n := uniform["miss"]; n < draws/5 || n > draws*3/10 {
+		t.Errorf("%d of %d names were misses, want about a quarter", n, draws)
+	}
+	for _, name := range names {
+		if n := uniform[name]; n < draws/16 || n > draws/8 {
+			t.Errorf("uniform picked %v %d times", name, n)
+		}
+	}
+
+	zipf := count(&workload{names: names, distribution: "zipf"})
+	if zipf["apple"] < 3*zipf["mango"] || zipf["miss"] != 0 {
+		t.Errorf("zipf is not skewed towards the first names: %v", zipf)
+	}
+}
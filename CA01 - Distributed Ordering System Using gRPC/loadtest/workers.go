@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"io"
+	"time"
+
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+)
+
+// serverWorker makes one server streaming call after the other until ctx
+// ends; the latency of a call runs from starting it to its last response
+func serverWorker(ctx context.Context, client pb.OrderServiceClient, p *picker, rec *recorder, namesPerCall int) {
+	names := make([]string, namesPerCall)
+	for ctx.Err() == nil {
+		for i := range names {
+			names[i] = p.next()
+		}
+		start := time.Now()
+		responses, err := serverCall(ctx, client, names)
+		if ctx.Err() != nil {
+			// cut off by the end of the run, not by the server
+			return
+		}
+		rec.record(time.Since(start), responses, err)
+	}
+}
+
+func serverCall(ctx context.Context, client pb.OrderServiceClient, names []string) (int, error) {
+	stream, err := client.GetOrderServerStreaming(ctx, &pb.NamesList{Names: names})
+	if err != nil {
+		return 0, err
+	}
+	responses := 0
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			return responses, nil
+		}
+		if err != nil {
+			return responses, err
+		}
+		responses++
+	}
+}
+
+// bidiWorker sends names one at a time on bidirectional sessions until ctx
+// ends, opening a new session after sessionRequests names or a failure. The
+// latency of a name runs from sending it to the last response to it.
+func bidiWorker(ctx context.Context, client pb.OrderServiceClient, p *picker, rec *recorder, sessionRequests int) {
+	for ctx.Err() == nil {
+		session(ctx, client, p, rec, sessionRequests)
+	}
+}
+
+func session(ctx context.Context, client pb.OrderServiceClient, p *picker, rec *recorder, requests int) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	start := time.Now()
+	stream, err := client.GetOrderBidirectionalStreaming(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			rec.record(time.Since(start), 0, err)
+		}
+		return
+	}
+	for seq := uint64(1); seq <= uint64(requests); seq++ {
+		start := time.Now()
+		// acknowledging every answered name keeps the server from holding
+		// responses for replay
+		err := stream.Send(&pb.OrderRequest{Name: p.next(), Seq: seq, Ack: seq - 1})
+		if err == io.EOF {
+			// the stream broke, Recv says why
+			_, err = stream.Recv()
+		}
+		responses := 0
+		for err == nil {
+			var res *pb.OrderResponse
+			if res, err = stream.Recv(); err == nil {
+				responses++
+				if res.Seq == seq && res.Last {
+					break
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		rec.record(time.Since(start), responses, err)
+		if err != nil {
+			return
+		}
+	}
+	stream.CloseSend()
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// zipfExponent skews the zipf distribution: the most popular name comes up
+// about as often as the next four together
+const zipfExponent = 1.2
+
+// workload decides which names the streams look up
+type workload struct {
+	names        []string
+	distribution string  // uniform or zipf
+	miss         float64 // fraction of names matching no item
+}
+
+func (w *workload) validate() error {
+	if len(w.names) == 0 {
+		return fmt.Errorf("no names to look up")
+	}
+	if w.distribution != "uniform" && w.distribution != "zipf" {
+		return fmt.Errorf("-distribution must be uniform or zipf, got %q", w.distribution)
+	}
+	if w.miss < 0 || w.miss > 1 {
+		return fmt.Errorf("-miss must be between 0 and 1, got %v", w.miss)
+	}
+	return nil
+}
+
+// picker draws names for one stream; it is not safe for concurrent use
+type picker struct {
+	w    *workload
+	rnd  *rand.Rand
+	zipf *rand.Zipf // nil for uniform
+}
+
+func (w *workload) picker(seed int64) *picker {
+	p := &picker{w: w, rnd: rand.New(rand.NewSource(seed))}
+	if w.distribution == "zipf" {
+		p.zipf = rand.NewZipf(p.rnd, zipfExponent, 1, uint64(len(w.names)-1))
+	}
+	return p
+}
+
+func (p *picker) next() string {
+	if p.w.miss > 0 && p.rnd.Float64() < p.w.miss {
+		// unlike a popular name, a miss goes through every fallback of the search
+		return fmt.Sprintf("no such item %d", p.rnd.Intn(1000000))
+	}
+	if p.zipf != nil {
+		return p.w.names[p.zipf.Uint64()]
+	}
+	return p.w.names[p.rnd.Intn(len(p.w.names))]
+}
@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"strings"
+)
+
+// contextHandler adds the attributes attached to the record's context
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs := attrsFrom(ctx); len(attrs) > 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// logOutput writes to the standard logger's output
+type logOutput struct{}
+
+func (logOutput) Write(p []byte) (int, error) {
+	return log.Writer().Write(p)
+}
+
+// plainHandler writes a record as one line through the standard logger, the
+// level in front unless it is info and the attributes as key=value after the
+// message. Going through the standard logger keeps its prefix and flags, and
+// a client that redirects it, to draw its prompt, gets these lines too.
+type plainHandler struct {
+	attrs []slog.Attr
+}
+
+func (plainHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h plainHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	if r.Level != slog.LevelInfo {
+		b.WriteString(r.Level.String())
+		b.WriteByte(' ')
+	}
+	b.WriteString(r.Message)
+	write := func(a slog.Attr) bool {
+		if !a.Equal(slog.Attr{}) {
+			b.WriteByte(' ')
+			b.WriteString(a.String())
+		}
+		return true
+	}
+	for _, a := range h.attrs {
+		write(a)
+	}
+	r.Attrs(write)
+	// caller depth of the logging functions is not tracked, the standard
+	// logger's file flags are not used by the programs
+	return log.Output(0, b.String())
+}
+
+func (h plainHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return plainHandler{append(h.attrs[:len(h.attrs):len(h.attrs)], attrs...)}
+}
+
+// WithGroup is not needed by the programs, groups are flattened
+func (h plainHandler) WithGroup(string) slog.Handler {
+	return h
+}
@@ -0,0 +1,158 @@
+// Package logging adds levels on top of the standard logger, so per-request
+// chatter can be turned off without losing lifecycle messages and errors.
+// Records go through log/slog: attributes attached to a context, such as the
+// trace ID of a call, end up on every line logged for that context.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+type Level int32
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+func (l Level) String() string {
+	switch l {
+	case Debug:
+		return "debug"
+	case Info:
+		return "info"
+	case Warn:
+		return "warn"
+	case Error:
+		return "error"
+	default:
+		return fmt.Sprintf("level(%d)", int32(l))
+	}
+}
+
+func (l Level) slog() slog.Level {
+	switch l {
+	case Debug:
+		return slog.LevelDebug
+	case Warn:
+		return slog.LevelWarn
+	case Error:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return Debug, nil
+	case "info", "":
+		return Info, nil
+	case "warn", "warning":
+		return Warn, nil
+	case "error":
+		return Error, nil
+	default:
+		return Info, fmt.Errorf("unknown log level %q, want debug, info, warn or error", s)
+	}
+}
+
+// Formats lists what SetFormat accepts: plain lines through the standard
+// logger, or slog's key=value text or JSON
+var Formats = []string{"plain", "text", "json"}
+
+var (
+	minLevel = int32(Info)
+	logger   atomic.Pointer[slog.Logger]
+)
+
+func init() {
+	logger.Store(slog.New(contextHandler{plainHandler{}}))
+}
+
+func SetLevel(l Level) {
+	atomic.StoreInt32(&minLevel, int32(l))
+}
+
+func Enabled(l Level) bool {
+	return int32(l) >= atomic.LoadInt32(&minLevel)
+}
+
+// SetFormat switches the output to one of Formats. Every format writes to the
+// standard logger's output, wherever it points at the time.
+func SetFormat(format string) error {
+	var h slog.Handler
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug} // Enabled filters first
+	switch format {
+	case "plain", "":
+		h = plainHandler{}
+	case "text":
+		h = slog.NewTextHandler(logOutput{}, opts)
+	case "json":
+		h = slog.NewJSONHandler(logOutput{}, opts)
+	default:
+		return fmt.Errorf("unknown log format %q, want %v", format, strings.Join(Formats, ", "))
+	}
+	logger.Store(slog.New(contextHandler{h}))
+	return nil
+}
+
+// Logf logs at level l with no context; info messages carry no prefix in the
+// plain format so they read like the plain log.Printf output they replace
+func Logf(l Level, format string, args ...interface{}) {
+	For(context.Background()).Logf(l, format, args...)
+}
+
+func Debugf(format string, args ...interface{}) { Logf(Debug, format, args...) }
+func Infof(format string, args ...interface{})  { Logf(Info, format, args...) }
+func Warnf(format string, args ...interface{})  { Logf(Warn, format, args...) }
+func Errorf(format string, args ...interface{}) { Logf(Error, format, args...) }
+
+// Logger logs with the attributes of a context
+type Logger struct {
+	ctx context.Context
+}
+
+// For returns a logger adding the attributes attached to ctx to every line
+func For(ctx context.Context) Logger {
+	return Logger{ctx}
+}
+
+func (l Logger) Logf(level Level, format string, args ...interface{}) {
+	if !Enabled(level) {
+		return
+	}
+	logger.Load().Log(l.ctx, level.slog(), fmt.Sprintf(format, args...))
+}
+
+func (l Logger) Debugf(format string, args ...interface{}) { l.Logf(Debug, format, args...) }
+func (l Logger) Infof(format string, args ...interface{})  { l.Logf(Info, format, args...) }
+func (l Logger) Warnf(format string, args ...interface{})  { l.Logf(Warn, format, args...) }
+func (l Logger) Errorf(format string, args ...interface{}) { l.Logf(Error, format, args...) }
+
+type attrsKey struct{}
+
+// WithAttrs returns ctx with key/value pairs, as slog takes them, added to
+// those every line logged for it carries
+func WithAttrs(ctx context.Context, args ...interface{}) context.Context {
+	r := slog.Record{}
+	r.Add(args...)
+	attrs := append([]slog.Attr(nil), attrsFrom(ctx)...)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return context.WithValue(ctx, attrsKey{}, attrs)
+}
+
+func attrsFrom(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
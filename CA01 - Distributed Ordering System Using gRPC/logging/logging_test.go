@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+)
+
+// capture sends the standard logger to a buffer for the test
+func capture(t *testing.T) *bytes.Buffer {
+	var b bytes.Buffer
+	flags, w := log.Flags(), log.Writer()
+	log.SetFlags(0)
+	log.SetOutput(&b)
+	t.Cleanup(func() {
+		log.SetFlags(flags)
+		log.SetOutput(w)
+		SetLevel(Info)
+		SetFormat("plain")
+	})
+	return &b
+}
+
+func TestPlainFormat(t *testing.T) {
+	b := capture(t)
+	ctx := WithAttrs(context.Background(), "trace_id", "abc")
+	ctx = WithAttrs(ctx, "session", 7)
+	For(ctx).Infof("Order %v confirmed", "ORD-1")
+	For(ctx).Debugf("dropped below the level")
+	Warnf("Catalog is not ready")
+
+	want := "Order ORD-1 confirmed trace_id=abc session=7\nWARN Catalog is not ready\n"
+	if b.String() != want {
+		t.Errorf("got\n%v\nwant\n%v", b.String(), want)
+	}
+}
+
+func TestAttrsDoNotLeakBetweenContexts(t *testing.T) {
+	b := capture(t)
+	parent := WithAttrs(context.Background(), "trace_id", "abc")
+	WithAttrs(parent, "session", "s1")
+	For(parent).Infof("parent")
+	if b.String() != "parent trace_id=abc\n" {
+		t.Errorf("got %q", b.String())
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	b := capture(t)
+	if err := SetFormat("json"); err != nil {
+		t.Fatal(err)
+	}
+	SetLevel(Debug)
+	For(WithAttrs(context.Background(), "trace_id", "abc")).Debugf("Got %d names", 2)
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &rec); err != nil {
+		t.Fatalf("%v in %q", err, b.String())
+	}
+	for key, want := range map[string]interface{}{"level": "DEBUG", "msg": "Got 2 names", "trace_id": "abc"} {
+		if rec[key] != want {
+			t.Errorf("%v is %v, want %v", key, rec[key], want)
+		}
+	}
+}
+
+func TestSetFormatRejectsUnknown(t *testing.T) {
+	capture(t)
+	if err := SetFormat("xml"); err == nil || !strings.Contains(err.Error(), "plain, text, json") {
+		t.Errorf("got %v", err)
+	}
+}
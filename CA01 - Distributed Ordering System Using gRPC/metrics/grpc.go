@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// message counts per stream, up to the server's limit of 1000 requests
+var messageBuckets = []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000}
+
+// GRPC measures the calls a server handles
+type GRPC struct {
+	started         *CounterVec
+	handled         *CounterVec
+	handlingSeconds *HistogramVec
+	received        *CounterVec
+	sent            *CounterVec
+	streamReceived  *HistogramVec
+	streamSent      *HistogramVec
+	activeStreams   *GaugeVec
+}
+
+// NewGRPC registers the gRPC server metrics with r
+func NewGRPC(r *Registry) *GRPC {
+	call := []string{"grpc_type", "grpc_service", "grpc_method"}
+	return &GRPC{
+		started: r.Counter("grpc_server_started_total",
+			"RPCs started on the server.", call...),
+		handled: r.Counter("grpc_server_handled_total",
+			"RPCs completed on the server, by status code.", append(call, "grpc_code")...),
+		handlingSeconds: r.Histogram("grpc_server_handling_seconds",
+			"Time from the start of an RPC to its status, in seconds.", DefaultBuckets, call...),
+		received: r.Counter("grpc_server_msg_received_total",
+			"Messages received from clients.", call...),
+		sent: r.Counter("grpc_server_msg_sent_total",
+			"Messages sent to clients.", call...),
+		streamReceived: r.Histogram("grpc_server_stream_msgs_received",
+			"Messages received per streaming RPC.", messageBuckets, call...),
+		streamSent: r.Histogram("grpc_server_stream_msgs_sent",
+			"Messages sent per streaming RPC.", messageBuckets, call...),
+		activeStreams: r.Gauge("grpc_server_active_streams",
+			"Streaming RPCs in progress.", call...),
+	}
+}
+
+func (g *GRPC) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		labels := callLabels("unary", info.FullMethod)
+		g.started.Inc(labels...)
+		g.received.Inc(labels...)
+		start := time.Now()
+		res, err := handler(ctx, req)
+		g.finish(labels, start, err)
+		if err == nil {
+			g.sent.Inc(labels...)
+		}
+		return res, err
+	}
+}
+
+func (g *GRPC) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		labels := callLabels(streamType(info), info.FullMethod)
+		g.started.Inc(labels...)
+		g.activeStreams.Add(1, labels...)
+		start := time.Now()
+		stream := &countingStream{ServerStream: ss, g: g, labels: labels}
+		err := handler(srv, stream)
+		g.activeStreams.Add(-1, labels...)
+		g.finish(labels, start, err)
+		g.streamReceived.Observe(float64(atomic.LoadInt64(&stream.received)), labels...)
+		g.streamSent.Observe(float64(atomic.LoadInt64(&stream.sent)), labels...)
+		return err
+	}
+}
+
+func (g *GRPC) finish(labels []string, start time.Time, err error) {
+	g.handled.Inc(append(labels[:len(labels):len(labels)], status.Code(err).String())...)
+	g.handlingSeconds.Observe(time.Since(start).Seconds(), labels...)
+}
+
+// countingStream counts the messages of one stream; handlers may receive on
+// another goroutine than the one they send on
+type countingStream struct {
+	grpc.ServerStream
+	g              *GRPC
+	labels         []string
+	sent, received int64 // atomic
+}
+
+func (s *countingStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		atomic.AddInt64(&s.sent, 1)
+		s.g.sent.Inc(s.labels...)
+	}
+	return err
+}
+
+func (s *countingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		atomic.AddInt64(&s.received, 1)
+		s.g.received.Inc(s.labels...)
+	}
+	return err
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi_stream"
+	case info.IsClientStream:
+		return "client_stream"
+	default:
+		return "server_stream"
+	}
+}
+
+// callLabels splits "/package.Service/Method" into the labels of a call
+func callLabels(typ, fullMethod string) []string {
+	service, method := "unknown", "unknown"
+	if i := strings.LastIndex(fullMethod, "/"); i > 0 {
+		service, method = fullMethod[1:i], fullMethod[i+1:]
+	}
+	return []string{typ, service, method}
+}
@@ -0,0 +1,243 @@
+// Package metrics keeps counters, gauges and histograms in memory and serves
+// them in the Prometheus text exposition format, along with gRPC interceptors
+// that measure every call.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are histogram upper bounds in seconds, from a millisecond to
+// ten seconds
+var DefaultBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type kind string
+
+const (
+	counter   kind = "counter"
+	gauge     kind = "gauge"
+	histogram kind = "histogram"
+)
+
+// Registry holds metric families by name
+type Registry struct {
+	mtx      sync.Mutex
+	families map[string]*family
+}
+
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// family is one named metric with a series per combination of label values
+type family struct {
+	name, help string
+	kind       kind
+	labels     []string
+	buckets    []float64 // histograms only
+
+	mtx    sync.Mutex
+	series map[string]*series // by joined label values
+}
+
+type series struct {
+	values []string
+	value  float64  // counters and gauges
+	counts []uint64 // histograms: observations per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+func (r *Registry) add(name, help string, k kind, buckets []float64, labels []string) *family {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.families[name]; ok {
+		panic("metrics: " + name + " registered twice")
+	}
+	f := &family{name: name, help: help, kind: k, labels: labels, buckets: buckets, series: make(map[string]*series)}
+	r.families[name] = f
+	return f
+}
+
+// with returns the series for values, creating it on first use
+func (f *family) with(values []string) *series {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %v takes %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{values: append([]string(nil), values...)}
+		if f.kind == histogram {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// CounterVec is a counter per combination of label values
+type CounterVec struct{ f *family }
+
+// Counter registers a counter; its name should end in _total
+func (r *Registry) Counter(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{r.add(name, help, counter, nil, labels)}
+}
+
+// Add adds delta, which must not be negative, to the series of values
+func (c *CounterVec) Add(delta float64, values ...string) {
+	if delta < 0 {
+		panic("metrics: counter " + c.f.name + " cannot decrease")
+	}
+	c.f.mtx.Lock()
+	defer c.f.mtx.Unlock()
+	c.f.with(values).value += delta
+}
+
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// GaugeVec is a gauge per combination of label values
+type GaugeVec struct{ f *family }
+
+func (r *Registry) Gauge(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{r.add(name, help, gauge, nil, labels)}
+}
+
+func (g *GaugeVec) Add(delta float64, values ...string) {
+	g.f.mtx.Lock()
+	defer g.f.mtx.Unlock()
+	g.f.with(values).value += delta
+}
+
+func (g *GaugeVec) Set(v float64, values ...string) {
+	g.f.mtx.Lock()
+	defer g.f.mtx.Unlock()
+	g.f.with(values).value = v
+}
+
+// HistogramVec is a histogram per combination of label values
+type HistogramVec struct{ f *family }
+
+// Histogram registers a histogram with the given increasing bucket upper
+// bounds; +Inf is implied
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if !sort.Float64sAreSorted(buckets) {
+		panic("metrics: buckets of " + name + " are not sorted")
+	}
+	return &HistogramVec{r.add(name, help, histogram, buckets, labels)}
+}
+
+func (h *HistogramVec) Observe(v float64, values ...string) {
+	h.f.mtx.Lock()
+	defer h.f.mtx.Unlock()
+	s := h.f.with(values)
+	if i := sort.SearchFloat64s(h.f.buckets, v); i < len(s.counts) {
+		s.counts[i]++
+	}
+	s.count++
+	s.sum += v
+}
+
+// WriteText writes every metric in the Prometheus text format, families and
+// series in a stable order
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mtx.Lock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mtx.Unlock()
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(bw)
+	}
+	return bw.Flush()
+}
+
+func (f *family) write(w *bufio.Writer) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	fmt.Fprintf(w, "# HELP %v %v\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %v %v\n", f.name, f.kind)
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := f.series[key]
+		labels := f.labelPairs(s.values)
+		if f.kind != histogram {
+			fmt.Fprintf(w, "%v%v %v\n", f.name, braces(labels), formatFloat(s.value))
+			continue
+		}
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%v_bucket%v %d\n", f.name, braces(withLE(labels, formatFloat(bound))), cumulative)
+		}
+		fmt.Fprintf(w, "%v_bucket%v %d\n", f.name, braces(withLE(labels, "+Inf")), s.count)
+		fmt.Fprintf(w, "%v_sum%v %v\n", f.name, braces(labels), formatFloat(s.sum))
+		fmt.Fprintf(w, "%v_count%v %d\n", f.name, braces(labels), s.count)
+	}
+}
+
+func (f *family) labelPairs(values []string) []string {
+	pairs := make([]string, len(values))
+	for i, v := range values {
+		pairs[i] = f.labels[i] + `="` + escapeLabel(v) + `"`
+	}
+	return pairs
+}
+
+// withLE adds the bucket bound label of a histogram series
+func withLE(pairs []string, bound string) []string {
+	return append(pairs[:len(pairs):len(pairs)], `le="`+bound+`"`)
+}
+
+func braces(pairs []string) string {
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, +1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// Handler serves the registry for Prometheus to scrape
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := r.WriteText(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	r := NewRegistry()
+	requests := r.Counter("requests_total", "Requests by path.", "path")
+	requests.Inc("/b")
+	requests.Add(2, `/a"quoted"`)
+	r.Gauge("temperature", "Current\ntemperature.").Set(-1.5)
+	latency := r.Histogram("latency_seconds", "Latency.", []float64{0.1, 1}, "op")
+	latency.Observe(0.05, "get")
+	latency.Observe(0.1, "get")
+	latency.Observe(3, "get")
+
+	var b strings.Builder
+	if err := r.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{op="get",le="0.1"} 2
+latency_seconds_bucket{op="get",le="1"} 2
+latency_seconds_bucket{op="get",le="+Inf"} 3
+latency_seconds_sum{op="get"} 3.15
+latency_seconds_count{op="get"} 3
+# HELP requests_total Requests by path.
+# TYPE requests_total counter
+requests_total{path="/a\"quoted\""} 2
+requests_total{path="/b"} 1
+# HELP temperature Current\ntemperature.
+# TYPE temperature gauge
+temperature -1.5
+`
+	if b.String() != want {
+		t.Errorf("got\n%v\nwant\n%v", b.String(), want)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("hits_total", "Hits.").Inc()
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "\nhits_total 1\n") {
+		t.Errorf("body:\n%v", rec.Body.String())
+	}
+}
+
+func TestMisuse(t *testing.T) {
+	r := NewRegistry()
+	c := r.Counter("c_total", "C.", "a")
+	for name, f := range map[string]func(){
+		"twice":          func() { r.Counter("c_total", "C again.") },
+		"label count":    func() { c.Inc("x", "y") },
+		"decrease":       func() { c.Add(-1, "x") },
+		"unsorted bound": func() { r.Histogram("h", "H.", []float64{1, 0.5}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%v did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
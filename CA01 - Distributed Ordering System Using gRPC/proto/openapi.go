@@ -0,0 +1,9 @@
+package proto
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI (Swagger 2.0) document of the REST/JSON gateway,
+// generated by protoc-gen-openapiv2 from the http options of ordering.proto
+//
+//go:embed ordering.swagger.json
+var OpenAPI []byte
@@ -22,10 +22,30 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OrderServiceClient interface {
-	// server streaming RPC
+	// server streaming RPC; GET /v1/search?names=apple&names=kiwi, or POST
+	// {"names": [...]} for long lists
 	GetOrderServerStreaming(ctx context.Context, in *NamesList, opts ...grpc.CallOption) (OrderService_GetOrderServerStreamingClient, error)
-	// bidirectional streaming RPC
+	// bidirectional streaming RPC. Each stream belongs to a session whose token
+	// comes back in the "session-token" response header, along with
+	// "session-seq", the last request sequence number the server handled. A
+	// client that lost its stream reconnects with the "session-token" and
+	// "session-ack" request headers: the server first replays the responses
+	// after session-ack, then carries on with requests after session-seq.
+	// Idle sessions expire. It has no REST mapping: sessions need both
+	// directions at once, which plain HTTP requests do not give.
 	GetOrderBidirectionalStreaming(ctx context.Context, opts ...grpc.CallOption) (OrderService_GetOrderBidirectionalStreamingClient, error)
+	// client streaming RPC: bulk upload of order lines, each placed as its own
+	// order; one summary answers the whole upload once the client closes it.
+	// No line is ordered before that, so a failed upload orders nothing.
+	// Over REST the body holds one line object after the other.
+	UploadOrders(ctx context.Context, opts ...grpc.CallOption) (OrderService_UploadOrdersClient, error)
+	// unary RPCs for the order lifecycle; orders are REST resources, a
+	// cancelled order stays readable
+	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	// marks a CONFIRMED order as shipped; its stock stays taken. Admins only.
+	FulfillOrder(ctx context.Context, in *FulfillOrderRequest, opts ...grpc.CallOption) (*Order, error)
 }
 
 type orderServiceClient struct {
@@ -99,14 +119,104 @@ func (x *orderServiceGetOrderBidirectionalStreamingClient) Recv() (*OrderRespons
 	return m, nil
 }
 
+func (c *orderServiceClient) UploadOrders(ctx context.Context, opts ...grpc.CallOption) (OrderService_UploadOrdersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &OrderService_ServiceDesc.Streams[2], "/order_service.OrderService/UploadOrders", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderServiceUploadOrdersClient{stream}
+	return x, nil
+}
+
+type OrderService_UploadOrdersClient interface {
+	Send(*UploadOrderLine) error
+	CloseAndRecv() (*UploadSummary, error)
+	grpc.ClientStream
+}
+
+type orderServiceUploadOrdersClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderServiceUploadOrdersClient) Send(m *UploadOrderLine) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *orderServiceUploadOrdersClient) CloseAndRecv() (*UploadSummary, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadSummary)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *orderServiceClient) PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/PlaceOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/GetOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) FulfillOrder(ctx context.Context, in *FulfillOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/FulfillOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility
 type OrderServiceServer interface {
-	// server streaming RPC
+	// server streaming RPC; GET /v1/search?names=apple&names=kiwi, or POST
+	// {"names": [...]} for long lists
 	GetOrderServerStreaming(*NamesList, OrderService_GetOrderServerStreamingServer) error
-	// bidirectional streaming RPC
+	// bidirectional streaming RPC. Each stream belongs to a session whose token
+	// comes back in the "session-token" response header, along with
+	// "session-seq", the last request sequence number the server handled. A
+	// client that lost its stream reconnects with the "session-token" and
+	// "session-ack" request headers: the server first replays the responses
+	// after session-ack, then carries on with requests after session-seq.
+	// Idle sessions expire. It has no REST mapping: sessions need both
+	// directions at once, which plain HTTP requests do not give.
 	GetOrderBidirectionalStreaming(OrderService_GetOrderBidirectionalStreamingServer) error
+	// client streaming RPC: bulk upload of order lines, each placed as its own
+	// order; one summary answers the whole upload once the client closes it.
+	// No line is ordered before that, so a failed upload orders nothing.
+	// Over REST the body holds one line object after the other.
+	UploadOrders(OrderService_UploadOrdersServer) error
+	// unary RPCs for the order lifecycle; orders are REST resources, a
+	// cancelled order stays readable
+	PlaceOrder(context.Context, *PlaceOrderRequest) (*Order, error)
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*Order, error)
+	// marks a CONFIRMED order as shipped; its stock stays taken. Admins only.
+	FulfillOrder(context.Context, *FulfillOrderRequest) (*Order, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -120,6 +230,21 @@ func (UnimplementedOrderServiceServer) GetOrderServerStreaming(*NamesList, Order
 func (UnimplementedOrderServiceServer) GetOrderBidirectionalStreaming(OrderService_GetOrderBidirectionalStreamingServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetOrderBidirectionalStreaming not implemented")
 }
+func (UnimplementedOrderServiceServer) UploadOrders(OrderService_UploadOrdersServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadOrders not implemented")
+}
+func (UnimplementedOrderServiceServer) PlaceOrder(context.Context, *PlaceOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) GetOrder(context.Context, *GetOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) FulfillOrder(context.Context, *FulfillOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FulfillOrder not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 
 // UnsafeOrderServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -180,13 +305,128 @@ func (x *orderServiceGetOrderBidirectionalStreamingServer) Recv() (*OrderRequest
 	return m, nil
 }
 
+func _OrderService_UploadOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(OrderServiceServer).UploadOrders(&orderServiceUploadOrdersServer{stream})
+}
+
+type OrderService_UploadOrdersServer interface {
+	SendAndClose(*UploadSummary) error
+	Recv() (*UploadOrderLine, error)
+	grpc.ServerStream
+}
+
+type orderServiceUploadOrdersServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderServiceUploadOrdersServer) SendAndClose(m *UploadSummary) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *orderServiceUploadOrdersServer) Recv() (*UploadOrderLine, error) {
+	m := new(UploadOrderLine)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _OrderService_PlaceOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).PlaceOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/PlaceOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).PlaceOrder(ctx, req.(*PlaceOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/GetOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/CancelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_FulfillOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FulfillOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).FulfillOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/FulfillOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).FulfillOrder(ctx, req.(*FulfillOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var OrderService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "order_service.OrderService",
 	HandlerType: (*OrderServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlaceOrder",
+			Handler:    _OrderService_PlaceOrder_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _OrderService_GetOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _OrderService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "FulfillOrder",
+			Handler:    _OrderService_FulfillOrder_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "GetOrderServerStreaming",
@@ -199,6 +439,309 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "UploadOrders",
+			Handler:       _OrderService_UploadOrders_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/ordering.proto",
+}
+
+// CatalogAdminServiceClient is the client API for CatalogAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CatalogAdminServiceClient interface {
+	AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*CatalogItem, error)
+	UpdateItem(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*CatalogItem, error)
+	RemoveItem(ctx context.Context, in *RemoveItemRequest, opts ...grpc.CallOption) (*CatalogItem, error)
+	// adds stock to an item, returning it with the new quantity
+	RestockItem(ctx context.Context, in *RestockItemRequest, opts ...grpc.CallOption) (*CatalogItem, error)
+	ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (*ListItemsResponse, error)
+	// server streaming RPC pushing every catalog change until the client hangs up
+	WatchCatalog(ctx context.Context, in *WatchCatalogRequest, opts ...grpc.CallOption) (CatalogAdminService_WatchCatalogClient, error)
+}
+
+type catalogAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogAdminServiceClient(cc grpc.ClientConnInterface) CatalogAdminServiceClient {
+	return &catalogAdminServiceClient{cc}
+}
+
+func (c *catalogAdminServiceClient) AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*CatalogItem, error) {
+	out := new(CatalogItem)
+	err := c.cc.Invoke(ctx, "/order_service.CatalogAdminService/AddItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminServiceClient) UpdateItem(ctx context.Context, in *UpdateItemRequest, opts ...grpc.CallOption) (*CatalogItem, error) {
+	out := new(CatalogItem)
+	err := c.cc.Invoke(ctx, "/order_service.CatalogAdminService/UpdateItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminServiceClient) RemoveItem(ctx context.Context, in *RemoveItemRequest, opts ...grpc.CallOption) (*CatalogItem, error) {
+	out := new(CatalogItem)
+	err := c.cc.Invoke(ctx, "/order_service.CatalogAdminService/RemoveItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminServiceClient) RestockItem(ctx context.Context, in *RestockItemRequest, opts ...grpc.CallOption) (*CatalogItem, error) {
+	out := new(CatalogItem)
+	err := c.cc.Invoke(ctx, "/order_service.CatalogAdminService/RestockItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminServiceClient) ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (*ListItemsResponse, error) {
+	out := new(ListItemsResponse)
+	err := c.cc.Invoke(ctx, "/order_service.CatalogAdminService/ListItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogAdminServiceClient) WatchCatalog(ctx context.Context, in *WatchCatalogRequest, opts ...grpc.CallOption) (CatalogAdminService_WatchCatalogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CatalogAdminService_ServiceDesc.Streams[0], "/order_service.CatalogAdminService/WatchCatalog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &catalogAdminServiceWatchCatalogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CatalogAdminService_WatchCatalogClient interface {
+	Recv() (*CatalogEvent, error)
+	grpc.ClientStream
+}
+
+type catalogAdminServiceWatchCatalogClient struct {
+	grpc.ClientStream
+}
+
+func (x *catalogAdminServiceWatchCatalogClient) Recv() (*CatalogEvent, error) {
+	m := new(CatalogEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CatalogAdminServiceServer is the server API for CatalogAdminService service.
+// All implementations must embed UnimplementedCatalogAdminServiceServer
+// for forward compatibility
+type CatalogAdminServiceServer interface {
+	AddItem(context.Context, *AddItemRequest) (*CatalogItem, error)
+	UpdateItem(context.Context, *UpdateItemRequest) (*CatalogItem, error)
+	RemoveItem(context.Context, *RemoveItemRequest) (*CatalogItem, error)
+	// adds stock to an item, returning it with the new quantity
+	RestockItem(context.Context, *RestockItemRequest) (*CatalogItem, error)
+	ListItems(context.Context, *ListItemsRequest) (*ListItemsResponse, error)
+	// server streaming RPC pushing every catalog change until the client hangs up
+	WatchCatalog(*WatchCatalogRequest, CatalogAdminService_WatchCatalogServer) error
+	mustEmbedUnimplementedCatalogAdminServiceServer()
+}
+
+// UnimplementedCatalogAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCatalogAdminServiceServer struct {
+}
+
+func (UnimplementedCatalogAdminServiceServer) AddItem(context.Context, *AddItemRequest) (*CatalogItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItem not implemented")
+}
+func (UnimplementedCatalogAdminServiceServer) UpdateItem(context.Context, *UpdateItemRequest) (*CatalogItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateItem not implemented")
+}
+func (UnimplementedCatalogAdminServiceServer) RemoveItem(context.Context, *RemoveItemRequest) (*CatalogItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveItem not implemented")
+}
+func (UnimplementedCatalogAdminServiceServer) RestockItem(context.Context, *RestockItemRequest) (*CatalogItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestockItem not implemented")
+}
+func (UnimplementedCatalogAdminServiceServer) ListItems(context.Context, *ListItemsRequest) (*ListItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListItems not implemented")
+}
+func (UnimplementedCatalogAdminServiceServer) WatchCatalog(*WatchCatalogRequest, CatalogAdminService_WatchCatalogServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCatalog not implemented")
+}
+func (UnimplementedCatalogAdminServiceServer) mustEmbedUnimplementedCatalogAdminServiceServer() {}
+
+// UnsafeCatalogAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogAdminServiceServer will
+// result in compilation errors.
+type UnsafeCatalogAdminServiceServer interface {
+	mustEmbedUnimplementedCatalogAdminServiceServer()
+}
+
+func RegisterCatalogAdminServiceServer(s grpc.ServiceRegistrar, srv CatalogAdminServiceServer) {
+	s.RegisterService(&CatalogAdminService_ServiceDesc, srv)
+}
+
+func _CatalogAdminService_AddItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogAdminServiceServer).AddItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.CatalogAdminService/AddItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogAdminServiceServer).AddItem(ctx, req.(*AddItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogAdminService_UpdateItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogAdminServiceServer).UpdateItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.CatalogAdminService/UpdateItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogAdminServiceServer).UpdateItem(ctx, req.(*UpdateItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogAdminService_RemoveItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogAdminServiceServer).RemoveItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.CatalogAdminService/RemoveItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogAdminServiceServer).RemoveItem(ctx, req.(*RemoveItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogAdminService_RestockItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestockItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogAdminServiceServer).RestockItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.CatalogAdminService/RestockItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogAdminServiceServer).RestockItem(ctx, req.(*RestockItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogAdminService_ListItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogAdminServiceServer).ListItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.CatalogAdminService/ListItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogAdminServiceServer).ListItems(ctx, req.(*ListItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogAdminService_WatchCatalog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCatalogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogAdminServiceServer).WatchCatalog(m, &catalogAdminServiceWatchCatalogServer{stream})
+}
+
+type CatalogAdminService_WatchCatalogServer interface {
+	Send(*CatalogEvent) error
+	grpc.ServerStream
+}
+
+type catalogAdminServiceWatchCatalogServer struct {
+	grpc.ServerStream
+}
+
+func (x *catalogAdminServiceWatchCatalogServer) Send(m *CatalogEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// CatalogAdminService_ServiceDesc is the grpc.ServiceDesc for CatalogAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CatalogAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "order_service.CatalogAdminService",
+	HandlerType: (*CatalogAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddItem",
+			Handler:    _CatalogAdminService_AddItem_Handler,
+		},
+		{
+			MethodName: "UpdateItem",
+			Handler:    _CatalogAdminService_UpdateItem_Handler,
+		},
+		{
+			MethodName: "RemoveItem",
+			Handler:    _CatalogAdminService_RemoveItem_Handler,
+		},
+		{
+			MethodName: "RestockItem",
+			Handler:    _CatalogAdminService_RestockItem_Handler,
+		},
+		{
+			MethodName: "ListItems",
+			Handler:    _CatalogAdminService_ListItems_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCatalog",
+			Handler:       _CatalogAdminService_WatchCatalog_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/ordering.proto",
 }
@@ -0,0 +1,349 @@
+package search
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+)
+
+// maxIndexedDistance is the largest edit distance the deletion index can
+// answer; fuzzy lookups beyond it fall back to scanning the vocabulary
+const maxIndexedDistance = 2
+
+type docID uint32
+
+type docSet map[docID]struct{}
+
+type doc struct {
+	item   catalog.Item
+	pos    int // position in the catalog
+	name   string
+	tokens []string
+}
+
+// Index is an inverted index over catalog item names. Lookups only score the
+// items having words that contain, or are a few typos away from, the query
+// words, so their cost follows the number of candidates rather than the
+// catalog size.
+type Index struct {
+	mtx     sync.RWMutex
+	nextID  docID
+	ids     map[string]docID // catalog item ID -> doc
+	docs    map[docID]*doc
+	order   []docID                        // docs in catalog order
+	words   map[string]docSet              // normalized words -> docs using them
+	grams   map[string]map[string]struct{} // trigrams -> words containing them
+	deletes map[string]map[string]struct{} // words with up to maxIndexedDistance runes deleted -> words
+}
+
+func NewIndex(items []catalog.Item) *Index {
+	idx := &Index{}
+	idx.Reset(items)
+	return idx
+}
+
+// Reset rebuilds the index from scratch
+func (idx *Index) Reset(items []catalog.Item) {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	idx.nextID = 0
+	idx.ids = make(map[string]docID, len(items))
+	idx.docs = make(map[docID]*doc, len(items))
+	idx.order = make([]docID, 0, len(items))
+	idx.words = make(map[string]docSet)
+	idx.grams = make(map[string]map[string]struct{})
+	idx.deletes = make(map[string]map[string]struct{})
+	for _, item := range items {
+		idx.add(item)
+	}
+}
+
+// Apply updates the index for one catalog change
+func (idx *Index) Apply(event catalog.Event) {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	switch event.Type {
+	case catalog.ItemAdded:
+		if _, ok := idx.ids[event.Item.ID]; !ok {
+			idx.add(event.Item)
+		}
+	case catalog.ItemUpdated:
+		id, ok := idx.ids[event.Item.ID]
+		if !ok {
+			idx.add(event.Item)
+			return
+		}
+		d := idx.docs[id]
+		if d.item.Name == event.Item.Name {
+			// stock changes are frequent and do not touch the postings
+			d.item = event.Item
+			return
+		}
+		idx.unlink(id, d)
+		d.item = event.Item
+		idx.link(id, d)
+	case catalog.ItemRemoved:
+		if id, ok := idx.ids[event.Item.ID]; ok {
+			idx.remove(id)
+		}
+	}
+}
+
+func (idx *Index) Len() int {
+	idx.mtx.RLock()
+	defer idx.mtx.RUnlock()
+	return len(idx.docs)
+}
+
+// ByName returns the first item in catalog order whose normalized name equals
+// the normalized name given
+func (idx *Index) ByName(name string) (catalog.Item, bool) {
+	tokens := Tokens(name)
+	if len(tokens) == 0 {
+		return catalog.Item{}, false
+	}
+	text := strings.Join(tokens, " ")
+
+	idx.mtx.RLock()
+	defer idx.mtx.RUnlock()
+
+	var best *doc
+	for id := range idx.words[tokens[0]] {
+		if d := idx.docs[id]; d.name == text && (best == nil || d.pos < best.pos) {
+			best = d
+		}
+	}
+	if best == nil {
+		return catalog.Item{}, false
+	}
+	return best.item, true
+}
+
+func (idx *Index) add(item catalog.Item) {
+	id := idx.nextID
+	idx.nextID++
+	d := &doc{item: item, pos: len(idx.order)}
+	idx.ids[item.ID] = id
+	idx.docs[id] = d
+	idx.order = append(idx.order, id)
+	idx.link(id, d)
+}
+
+func (idx *Index) remove(id docID) {
+	d := idx.docs[id]
+	idx.unlink(id, d)
+	delete(idx.ids, d.item.ID)
+	delete(idx.docs, id)
+
+	// removals are rare admin operations, so shifting the tail is fine
+	idx.order = append(idx.order[:d.pos], idx.order[d.pos+1:]...)
+	for pos := d.pos; pos < len(idx.order); pos++ {
+		idx.docs[idx.order[pos]].pos = pos
+	}
+}
+
+// link adds d to the postings under its current name; a word seen for the
+// first time also goes into the trigram and deletion indexes
+func (idx *Index) link(id docID, d *doc) {
+	d.tokens = Tokens(d.item.Name)
+	d.name = strings.Join(d.tokens, " ")
+	for _, word := range d.tokens {
+		if _, known := idx.words[word]; !known {
+			for _, gram := range trigrams(word) {
+				addWord(idx.grams, gram, word)
+			}
+			for _, variant := range deletions(word, maxIndexedDistance) {
+				addWord(idx.deletes, variant, word)
+			}
+		}
+		addPosting(idx.words, word, id)
+	}
+}
+
+// unlink drops d from the postings, forgetting words no other doc uses
+func (idx *Index) unlink(id docID, d *doc) {
+	for _, word := range d.tokens {
+		removePosting(idx.words, word, id)
+		if _, still := idx.words[word]; still {
+			continue
+		}
+		for _, gram := range trigrams(word) {
+			removeWord(idx.grams, gram, word)
+		}
+		for _, variant := range deletions(word, maxIndexedDistance) {
+			removeWord(idx.deletes, variant, word)
+		}
+	}
+}
+
+// containing returns the docs with a word containing the given word
+func (idx *Index) containing(word string) docSet {
+	found := docSet{}
+	grams := trigrams(word)
+	if len(grams) == 0 {
+		// too short for a trigram, check the whole vocabulary
+		for w, postings := range idx.words {
+			if strings.Contains(w, word) {
+				union(found, postings)
+			}
+		}
+		return found
+	}
+
+	// walk the rarest trigram's words; a word holding every trigram may still
+	// have them in another order, hence the final Contains
+	rarest := idx.grams[grams[0]]
+	for _, gram := range grams[1:] {
+		if words := idx.grams[gram]; len(words) < len(rarest) {
+			rarest = words
+		}
+	}
+	for w := range rarest {
+		if strings.Contains(w, word) {
+			union(found, idx.words[w])
+		}
+	}
+	return found
+}
+
+// near returns the docs having a word within distance of word
+func (idx *Index) near(word string, distance int) docSet {
+	found := docSet{}
+	if distance > maxIndexedDistance {
+		for w, postings := range idx.words {
+			if Distance(word, w) <= distance {
+				union(found, postings)
+			}
+		}
+		return found
+	}
+
+	checked := map[string]bool{}
+	for _, variant := range deletions(word, distance) {
+		for w := range idx.deletes[variant] {
+			if checked[w] {
+				continue
+			}
+			checked[w] = true
+			if Distance(word, w) <= distance {
+				union(found, idx.words[w])
+			}
+		}
+	}
+	return found
+}
+
+func trigrams(s string) []string {
+	runes := []rune(s)
+	if len(runes) < 3 {
+		return nil
+	}
+	seen := make(map[string]bool, len(runes)-2)
+	grams := make([]string, 0, len(runes)-2)
+	for i := 0; i+3 <= len(runes); i++ {
+		gram := string(runes[i : i+3])
+		if !seen[gram] {
+			seen[gram] = true
+			grams = append(grams, gram)
+		}
+	}
+	return grams
+}
+
+// deletions lists s and every string obtained by deleting up to n runes from
+// it. Two words within optimal string alignment distance n always share one.
+func deletions(s string, n int) []string {
+	seen := map[string]bool{s: true}
+	level := []string{s}
+	for ; n > 0; n-- {
+		var next []string
+		for _, w := range level {
+			for i := range w {
+				_, size := utf8.DecodeRuneInString(w[i:])
+				v := w[:i] + w[i+size:]
+				if !seen[v] {
+					seen[v] = true
+					next = append(next, v)
+				}
+			}
+		}
+		level = next
+	}
+	variants := make([]string, 0, len(seen))
+	for v := range seen {
+		variants = append(variants, v)
+	}
+	return variants
+}
+
+func addWord(index map[string]map[string]struct{}, key, word string) {
+	words, ok := index[key]
+	if !ok {
+		words = make(map[string]struct{})
+		index[key] = words
+	}
+	words[word] = struct{}{}
+}
+
+func removeWord(index map[string]map[string]struct{}, key, word string) {
+	delete(index[key], word)
+	if len(index[key]) == 0 {
+		delete(index, key)
+	}
+}
+
+func addPosting(postings map[string]docSet, key string, id docID) {
+	set, ok := postings[key]
+	if !ok {
+		set = docSet{}
+		postings[key] = set
+	}
+	set[id] = struct{}{}
+}
+
+func removePosting(postings map[string]docSet, key string, id docID) {
+	delete(postings[key], id)
+	if len(postings[key]) == 0 {
+		delete(postings, key)
+	}
+}
+
+// intersect walks the smallest set and probes the others
+func intersect(sets []docSet) docSet {
+	if len(sets) == 0 {
+		return nil
+	}
+	smallest := 0
+	for i, set := range sets {
+		if len(set) < len(sets[smallest]) {
+			smallest = i
+		}
+	}
+	found := docSet{}
+	for id := range sets[smallest] {
+		inAll := true
+		for i, set := range sets {
+			if i == smallest {
+				continue
+			}
+			if _, ok := set[id]; !ok {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			found[id] = struct{}{}
+		}
+	}
+	return found
+}
+
+func union(dst, src docSet) {
+	for id := range src {
+		dst[id] = struct{}{}
+	}
+}
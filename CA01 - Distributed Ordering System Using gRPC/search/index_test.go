@@ -0,0 +1,178 @@
+package search
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+)
+
+// syntheticCatalog builds n items named from a vocabulary that grows with the
+// catalog, like real SKU names do, so each word is shared by a handful of items
+func syntheticCatalog(r *rand.Rand, n int) ([]catalog.Item, []string) {
+	vocab := make([]string, n/4+10)
+	for i := range vocab {
+		vocab[i] = pseudoWord(r)
+	}
+	items := make([]catalog.Item, n)
+	for i := range items {
+		words := make([]string, 2+r.Intn(2))
+		for j := range words {
+			words[j] = vocab[r.Intn(len(vocab))]
+		}
+		items[i] = catalog.Item{ID: fmt.Sprintf("item-%d", i), Name: strings.Join(words, " "), Quantity: int32(r.Intn(100))}
+	}
+	return items, vocab
+}
+
+func pseudoWord(r *rand.Rand) string {
+	b := make([]byte, 4+r.Intn(6))
+	for i := range b {
+		b[i] = byte('a' + r.Intn(26))
+	}
+	return string(b)
+}
+
+// typo swaps, drops or replaces one rune
+func typo(r *rand.Rand, word string) string {
+	i := r.Intn(len(word) - 1)
+	switch r.Intn(3) {
+	case 0:
+		return word[:i] + word[i+1:i+2] + word[i:i+1] + word[i+2:]
+	case 1:
+		return word[:i] + word[i+1:]
+	default:
+		return word[:i] + "x" + word[i+1:]
+	}
+}
+
+// queries mixes whole words, typos, prefixes, full names and fragments
+func queries(r *rand.Rand, items []catalog.Item, vocab []string, n int) []string {
+	var qs []string
+	for i := 0; i < n; i++ {
+		word := vocab[r.Intn(len(vocab))]
+		name := items[r.Intn(len(items))].Name
+		qs = append(qs, word, typo(r, word), word[:3], name, strings.ToUpper(name), word[1:len(word)-1]+" "+vocab[r.Intn(len(vocab))][:2])
+	}
+	return append(qs, "", "zz", "qqqq", "a")
+}
+
+func TestIndexMatchesLinearScan(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	items, vocab := syntheticCatalog(r, 2000)
+	idx := NewIndex(items)
+
+	// apply a mix of changes to both the item list and the index
+	for i := 0; i < 300; i++ {
+		switch k := r.Intn(len(items)); r.Intn(3) {
+		case 0:
+			item := catalog.Item{ID: fmt.Sprintf("new-%d", i), Name: vocab[r.Intn(len(vocab))] + " " + pseudoWord(r)}
+			items = append(items, item)
+			idx.Apply(catalog.Event{Type: catalog.ItemAdded, Item: item})
+		case 1:
+			items[k].Name = pseudoWord(r) + " " + vocab[r.Intn(len(vocab))]
+			items[k].Quantity++
+			idx.Apply(catalog.Event{Type: catalog.ItemUpdated, Item: items[k]})
+		case 2:
+			removed := items[k]
+			items = append(items[:k], items[k+1:]...)
+			idx.Apply(catalog.Event{Type: catalog.ItemRemoved, Item: removed})
+		}
+	}
+	if idx.Len() != len(items) {
+		t.Fatalf("index holds %d items, catalog %d", idx.Len(), len(items))
+	}
+
+	e := NewEngine(Options{})
+	for _, q := range queries(r, items, vocab, 40) {
+		got, want := e.Search(q, idx), e.SearchItems(q, items)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("query %q: index returned %d results, scan %d\nindex: %v\nscan:  %v", q, len(got), len(want), got, want)
+		}
+	}
+}
+
+func TestSearchRanking(t *testing.T) {
+	idx := NewIndex(catalog.DefaultItems())
+	e := NewEngine(Options{MaxResults: 2})
+	tests := []struct {
+		query string
+		want  []string
+		kind  MatchKind
+	}{
+		{"apple", []string{"apple", "red apple"}, Exact},
+		{"APPLE", []string{"apple", "red apple"}, Exact},
+		{"banan", []string{"banana"}, Prefix},
+		{"cheery", []string{"cherry"}, Fuzzy},
+		{"apple red", []string{"red apple"}, Token},
+		{"ang", []string{"mango", "orange"}, Substring},
+		{"xyz", nil, 0},
+	}
+	for _, tt := range tests {
+		results := e.Search(tt.query, idx)
+		var names []string
+		for _, res := range results {
+			names = append(names, res.Item.Name)
+		}
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, names, tt.want)
+		}
+		if len(results) > 0 && results[0].Kind != tt.kind {
+			t.Errorf("Search(%q) best match kind = %v, want %v", tt.query, results[0].Kind, tt.kind)
+		}
+	}
+}
+
+// selectiveQueries are whole words, typos and full names: their matches stay
+// a handful however large the catalog gets
+func selectiveQueries(r *rand.Rand, items []catalog.Item, vocab []string, n int) []string {
+	var qs []string
+	for i := 0; i < n; i++ {
+		word := vocab[r.Intn(len(vocab))]
+		qs = append(qs, word, typo(r, word), items[r.Intn(len(items))].Name)
+	}
+	return qs
+}
+
+// The per-lookup cost of the index should stay roughly flat as the catalog
+// grows, while the linear scan grows with it. Two or three letter fragments
+// match a fixed share of the catalog, so those lookups are excluded:
+//
+//	go test ./search -run XXX -bench Search
+func BenchmarkSearch(b *testing.B) {
+	for _, size := range []int{1000, 10000, 100000, 300000} {
+		r := rand.New(rand.NewSource(int64(size)))
+		items, vocab := syntheticCatalog(r, size)
+		qs := selectiveQueries(r, items, vocab, 200)
+		idx := NewIndex(items)
+		e := NewEngine(Options{MaxResults: 10})
+
+		b.Run(fmt.Sprintf("index/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				e.Search(qs[i%len(qs)], idx)
+			}
+		})
+		if size <= 10000 {
+			b.Run(fmt.Sprintf("scan/%d", size), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					e.SearchItems(qs[i%len(qs)], items)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkIndexApply(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	items, vocab := syntheticCatalog(r, 100000)
+	idx := NewIndex(items)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		item := items[i%len(items)]
+		item.Name = vocab[i%len(vocab)] + " " + vocab[(i*7)%len(vocab)]
+		idx.Apply(catalog.Event{Type: catalog.ItemUpdated, Item: item})
+	}
+}
@@ -0,0 +1,34 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var folder = cases.Fold()
+
+// Normalize folds case, strips diacritics and collapses everything that is
+// not a letter or digit into single spaces, so "  Crème-Brûlée " and
+// "creme brulee" compare equal
+func Normalize(s string) string {
+	return strings.Join(Tokens(s), " ")
+}
+
+// Tokens splits a string into normalized words
+func Tokens(s string) []string {
+	// decompose so accents become separate marks we can drop, then recompose
+	t := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(t, s)
+	if err != nil {
+		stripped = s
+	}
+	folded := folder.String(stripped)
+	return strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,270 @@
+// Package search ranks catalog items against a free-text query using
+// normalized exact, prefix, token, substring and edit-distance matching.
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+)
+
+type MatchKind int
+
+const (
+	Exact     MatchKind = iota + 1 // whole name equals the query
+	Prefix                         // name starts with the query
+	Token                          // every query word is (the start of) a word of the name
+	Substring                      // name contains the query
+	Fuzzy                          // name is within the allowed edit distance
+)
+
+func (k MatchKind) String() string {
+	switch k {
+	case Exact:
+		return "exact"
+	case Prefix:
+		return "prefix"
+	case Token:
+		return "token"
+	case Substring:
+		return "substring"
+	case Fuzzy:
+		return "fuzzy"
+	default:
+		return "unknown"
+	}
+}
+
+type Options struct {
+	// MaxResults caps how many results Search returns; 0 means no cap
+	MaxResults int
+	// MaxDistance caps the edit distance of fuzzy matches; 0 scales it with
+	// the query length
+	MaxDistance int
+}
+
+type Result struct {
+	Item  catalog.Item
+	Index int // position of the item in the catalog
+	Kind  MatchKind
+	Score float64 // relevance in (0, 1], higher is better
+}
+
+type Engine struct {
+	opts Options
+}
+
+func NewEngine(opts Options) *Engine {
+	return &Engine{opts: opts}
+}
+
+// Search returns the indexed items matching query, best first; equally
+// scored items keep their catalog order
+func (e *Engine) Search(query string, idx *Index) []Result {
+	q := newQuery(query)
+	if q.text == "" {
+		return nil
+	}
+
+	idx.mtx.RLock()
+	defer idx.mtx.RUnlock()
+
+	var results []Result
+	for id := range e.candidates(q, idx) {
+		d := idx.docs[id]
+		if kind, score, ok := e.match(q, d.name, d.tokens); ok {
+			results = append(results, Result{Item: d.item, Index: d.pos, Kind: kind, Score: score})
+		}
+	}
+	return e.rank(results)
+}
+
+// SearchItems is Search without an index: it scores every item. It serves as
+// the reference the index has to agree with.
+func (e *Engine) SearchItems(query string, items []catalog.Item) []Result {
+	q := newQuery(query)
+	if q.text == "" {
+		return nil
+	}
+
+	var results []Result
+	for i, item := range items {
+		tokens := Tokens(item.Name)
+		if kind, score, ok := e.match(q, strings.Join(tokens, " "), tokens); ok {
+			results = append(results, Result{Item: item, Index: i, Kind: kind, Score: score})
+		}
+	}
+	return e.rank(results)
+}
+
+// candidates narrows the index down to the docs that can match q: every exact,
+// prefix, substring or token match contains each query word, and every fuzzy
+// match has a word close to each query word
+func (e *Engine) candidates(q query, idx *Index) docSet {
+	var containing, near []docSet
+	distance := e.maxDistance(q)
+	for _, word := range q.tokens {
+		containing = append(containing, idx.containing(word))
+		if distance > 0 {
+			near = append(near, idx.near(word, distance))
+		}
+	}
+
+	found := intersect(containing)
+	if found == nil {
+		found = docSet{}
+	}
+	if len(near) > 0 {
+		union(found, intersect(near))
+	}
+	return found
+}
+
+func (e *Engine) rank(results []Result) []Result {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Index < results[j].Index
+	})
+	if e.opts.MaxResults > 0 && len(results) > e.opts.MaxResults {
+		results = results[:e.opts.MaxResults]
+	}
+	return results
+}
+
+// Suggest returns up to n item names closest to query by edit distance, for
+// "did you mean" hints when Search finds nothing
+func (e *Engine) Suggest(query string, idx *Index, n int) []string {
+	q := newQuery(query)
+	if q.text == "" || n <= 0 {
+		return nil
+	}
+	limit := q.length/2 + 1
+
+	idx.mtx.RLock()
+	defer idx.mtx.RUnlock()
+
+	// any word close to any query word is a candidate
+	found := docSet{}
+	for _, word := range q.tokens {
+		union(found, idx.near(word, min(limit, maxIndexedDistance)))
+	}
+
+	type candidate struct {
+		name     string
+		distance int
+		pos      int
+	}
+	var candidates []candidate
+	for id := range found {
+		d := idx.docs[id]
+		if distance := q.fuzzyDistance(d.tokens); distance <= limit {
+			candidates = append(candidates, candidate{d.item.Name, distance, d.pos})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].pos < candidates[j].pos
+	})
+
+	var names []string
+	for _, c := range candidates {
+		if len(names) == n {
+			break
+		}
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// match scores a normalized name and its words against q
+func (e *Engine) match(q query, name string, tokens []string) (MatchKind, float64, bool) {
+	// how much of the name the query covers, used to break ties within a kind
+	coverage := float64(q.length) / float64(utf8.RuneCountInString(name))
+
+	switch {
+	case name == "":
+		return 0, 0, false
+	case name == q.text:
+		return Exact, 1, true
+	case strings.HasPrefix(name, q.text):
+		return Prefix, 0.85 + 0.1*coverage, true
+	case q.allTokens(tokens, func(t, qt string) bool { return t == qt }):
+		return Token, 0.75 + 0.1*coverage, true
+	case q.allTokens(tokens, strings.HasPrefix):
+		return Token, 0.65 + 0.1*coverage, true
+	case strings.Contains(name, q.text):
+		return Substring, 0.5 + 0.1*coverage, true
+	}
+
+	if d := q.fuzzyDistance(tokens); d <= e.maxDistance(q) {
+		return Fuzzy, 0.4*(1-float64(d)/float64(q.length+1)) + 0.05*min(coverage, 1), true
+	}
+	return 0, 0, false
+}
+
+func (e *Engine) maxDistance(q query) int {
+	if e.opts.MaxDistance > 0 {
+		return e.opts.MaxDistance
+	}
+	switch {
+	case q.length < 3:
+		return 0 // one typo in a two letter query matches almost anything
+	case q.length <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+type query struct {
+	text   string
+	tokens []string
+	length int // in runes
+}
+
+func newQuery(s string) query {
+	tokens := Tokens(s)
+	text := strings.Join(tokens, " ")
+	return query{text: text, tokens: tokens, length: utf8.RuneCountInString(text)}
+}
+
+// allTokens reports whether every query token matches some name token
+func (q query) allTokens(nameTokens []string, matches func(nameToken, queryToken string) bool) bool {
+	for _, qt := range q.tokens {
+		found := false
+		for _, t := range nameTokens {
+			if matches(t, qt) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// fuzzyDistance sums each query word's distance to its closest name word
+func (q query) fuzzyDistance(nameTokens []string) int {
+	if len(nameTokens) == 0 {
+		return int(^uint(0) >> 1)
+	}
+	sum := 0
+	for _, qt := range q.tokens {
+		closest := -1
+		for _, t := range nameTokens {
+			if d := Distance(qt, t); closest < 0 || d < closest {
+				closest = d
+			}
+		}
+		sum += closest
+	}
+	return sum
+}
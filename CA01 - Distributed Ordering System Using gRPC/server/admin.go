@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+type adminServer struct {
+	pb.CatalogAdminServiceServer
+	catalog catalog.Catalog
+	// closed when the server shuts down, ending watches that would otherwise
+	// hold up the drain
+	shutdown <-chan struct{}
+}
+
+func (s *adminServer) AddItem(ctx context.Context, req *pb.AddItemRequest) (*pb.CatalogItem, error) {
+	item := fromProtoItem(req.Item)
+	if item.ID == "" {
+		item.ID = newItemID()
+	}
+	logCaller(ctx, logging.Info, "Adding catalog item %v (%v)", item.ID, item.Name)
+	if err := s.catalog.Add(item); err != nil {
+		return nil, catalogError(err)
+	}
+	return toProtoItem(item), nil
+}
+
+func (s *adminServer) UpdateItem(ctx context.Context, req *pb.UpdateItemRequest) (*pb.CatalogItem, error) {
+	if req.Item.GetId() == "" {
+		return nil, invalidField("item.id", "item id is required").Err()
+	}
+	paths := req.UpdateMask.GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"name", "quantity"}
+	}
+	for _, path := range paths {
+		if path != "name" && path != "quantity" {
+			return nil, invalidField("update_mask", fmt.Sprintf("unknown field %q", path)).Err()
+		}
+	}
+
+	logCaller(ctx, logging.Info, "Updating catalog item %v: %v", req.Item.Id, paths)
+	// only the masked fields change, on the item as it is when the update
+	// applies: a rename keeps whatever stock orders left meanwhile
+	item, err := s.catalog.Update(req.Item.Id, func(item *catalog.Item) error {
+		for _, path := range paths {
+			switch path {
+			case "name":
+				item.Name = req.Item.Name
+			case "quantity":
+				item.Quantity = req.Item.Quantity
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toProtoItem(item), nil
+}
+
+func (s *adminServer) RemoveItem(ctx context.Context, req *pb.RemoveItemRequest) (*pb.CatalogItem, error) {
+	item, err := s.catalog.Get(req.Id)
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	logCaller(ctx, logging.Info, "Removing catalog item %v", req.Id)
+	if err := s.catalog.Remove(req.Id); err != nil {
+		return nil, catalogError(err)
+	}
+	return toProtoItem(item), nil
+}
+
+func (s *adminServer) RestockItem(ctx context.Context, req *pb.RestockItemRequest) (*pb.CatalogItem, error) {
+	if req.Id == "" {
+		return nil, invalidField("id", "item id is required").Err()
+	}
+	if req.Quantity <= 0 {
+		return nil, invalidField("quantity", fmt.Sprintf("quantity must be positive, got %d", req.Quantity)).Err()
+	}
+	logCaller(ctx, logging.Info, "Restocking catalog item %v with %d", req.Id, req.Quantity)
+	item, err := s.catalog.Restock(req.Id, req.Quantity)
+	if err != nil {
+		return nil, catalogError(err)
+	}
+	return toProtoItem(item), nil
+}
+
+func (s *adminServer) ListItems(ctx context.Context, req *pb.ListItemsRequest) (*pb.ListItemsResponse, error) {
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize < 0:
+		return nil, invalidField("page_size", "page size must not be negative").Err()
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, invalidField("page_token", "malformed page token").Err()
+	}
+
+	items, err := s.catalog.Items()
+	if err != nil {
+		return nil, catalogUnavailable(err)
+	}
+	res := &pb.ListItemsResponse{TotalSize: int32(len(items))}
+	if offset > len(items) {
+		offset = len(items)
+	}
+	end := offset + pageSize
+	if end < len(items) {
+		res.NextPageToken = encodePageToken(end)
+	} else {
+		end = len(items)
+	}
+	for _, item := range items[offset:end] {
+		res.Items = append(res.Items, toProtoItem(item))
+	}
+	return res, nil
+}
+
+func (s *adminServer) WatchCatalog(req *pb.WatchCatalogRequest, stream pb.CatalogAdminService_WatchCatalogServer) error {
+	// subscribe before taking the snapshot so no change falls in between
+	events, cancel := s.catalog.Subscribe()
+	defer cancel()
+	logCaller(stream.Context(), logging.Debug, "Catalog watcher connected")
+
+	if req.IncludeSnapshot {
+		items, err := s.catalog.Items()
+		if err != nil {
+			return catalogUnavailable(err)
+		}
+		for _, item := range items {
+			if err := stream.Send(toProtoEvent(catalog.Event{Type: catalog.ItemAdded, Item: item})); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			logCaller(stream.Context(), logging.Debug, "Catalog watcher disconnected")
+			return nil
+		case <-s.shutdown:
+			return status.Error(codes.Unavailable, "server is shutting down, watch again to resume")
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.Aborted, "catalog watch ended, the watcher fell behind or the catalog closed")
+			}
+			if err := stream.Send(toProtoEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// catalogError maps catalog package errors to gRPC status errors
+func catalogError(err error) error {
+	switch {
+	case errors.Is(err, catalog.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, catalog.ErrExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, catalog.ErrInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, catalog.ErrInsufficientStock):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return catalogUnavailable(err)
+	}
+}
+
+func newItemID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "item-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return "item-" + hex.EncodeToString(b)
+}
+
+// page tokens are opaque to clients but are just the offset of the next page
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("bad offset %q", raw)
+	}
+	return offset, nil
+}
+
+func fromProtoItem(item *pb.CatalogItem) catalog.Item {
+	return catalog.Item{ID: item.GetId(), Name: item.GetName(), Quantity: item.GetQuantity()}
+}
+
+func toProtoItem(item catalog.Item) *pb.CatalogItem {
+	return &pb.CatalogItem{Id: item.ID, Name: item.Name, Quantity: item.Quantity}
+}
+
+func toProtoEvent(event catalog.Event) *pb.CatalogEvent {
+	var eventType pb.CatalogEventType
+	switch event.Type {
+	case catalog.ItemAdded:
+		eventType = pb.CatalogEventType_ITEM_ADDED
+	case catalog.ItemUpdated:
+		eventType = pb.CatalogEventType_ITEM_UPDATED
+	case catalog.ItemRemoved:
+		eventType = pb.CatalogEventType_ITEM_REMOVED
+	}
+	return &pb.CatalogEvent{
+		Type:      eventType,
+		Item:      toProtoItem(event.Item),
+		Timestamp: time.Now().UnixMilli(),
+	}
+}
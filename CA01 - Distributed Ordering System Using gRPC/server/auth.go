@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+
+	"github.com/m-hariri/basic-go-grpc/auth"
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const adminRole = "admin"
+
+func adminMethod(name string) string {
+	return "/" + pb.CatalogAdminService_ServiceDesc.ServiceName + "/" + name
+}
+
+func orderMethod(name string) string {
+	return "/" + pb.OrderService_ServiceDesc.ServiceName + "/" + name
+}
+
+// authPolicy lets any authenticated caller search, order and read the catalog,
+// while changing the catalog and fulfilling orders is reserved to admins.
+// Health checks stay open for load balancers.
+var authPolicy = auth.Policy{
+	Public: []string{"/" + healthpb.Health_ServiceDesc.ServiceName + "/"},
+	Roles: map[string][]string{
+		orderMethod("FulfillOrder"): {adminRole},
+		adminMethod("AddItem"):      {adminRole},
+		adminMethod("UpdateItem"):   {adminRole},
+		adminMethod("RemoveItem"):   {adminRole},
+		adminMethod("RestockItem"):  {adminRole},
+	},
+}
+
+// newAuthenticator returns nil when neither a keys file nor a JWT key is set,
+// leaving the server open
+func newAuthenticator(keysFile, jwtKeyFile string) (*auth.Authenticator, error) {
+	if keysFile == "" && jwtKeyFile == "" {
+		return nil, nil
+	}
+	a := &auth.Authenticator{Policy: authPolicy}
+	if keysFile != "" {
+		keys, err := auth.LoadAPIKeys(keysFile)
+		if err != nil {
+			return nil, err
+		}
+		a.Keys = keys
+	}
+	if jwtKeyFile != "" {
+		key, err := auth.LoadJWTKey(jwtKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		a.JWT = auth.NewJWT(key)
+	}
+	return a, nil
+}
+
+// caller names the authenticated caller for logs
+func caller(ctx context.Context) string {
+	if id, ok := auth.FromContext(ctx); ok {
+		return id.Subject
+	}
+	return "anonymous"
+}
+
+// logCaller logs for a call, with its caller in front and the attributes of
+// ctx, such as the trace ID, after the message
+func logCaller(ctx context.Context, level logging.Level, format string, args ...interface{}) {
+	logging.For(ctx).Logf(level, "[%v] "+format, append([]interface{}{caller(ctx)}, args...)...)
+}
@@ -1,45 +1,143 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"io"
-	"log"
 	"strconv"
 	"strings"
 
+	"github.com/m-hariri/basic-go-grpc/logging"
 	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func (s *orderServer) GetOrderBidirectionalStreaming(stream pb.OrderService_GetOrderBidirectionalStreamingServer) error {
+	// cancelled as well when another stream takes the session over
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	token, ack, err := resumeRequest(ctx)
+	if err != nil {
+		return err
+	}
+	sess, err := s.sessions.attach(token, caller(ctx), cancel)
+	if err != nil {
+		logCaller(ctx, logging.Info, "Cannot resume session %v: %v", token, err)
+		return err
+	}
+	defer s.sessions.release(sess)
+	ctx = logging.WithAttrs(ctx, "session", sess.token)
+	if token != "" && ack < sess.forgotten {
+		return responsesLost(ack, sess.forgotten)
+	}
+
+	header := metadata.Pairs(sessionTokenKey, sess.token, sessionSeqKey, strconv.FormatUint(sess.processed, 10))
+	if err := stream.SendHeader(header); err != nil {
+		return err
+	}
+	// whatever the last stream sent after ack may never have arrived
+	sess.acknowledge(ack)
+	for _, res := range sess.pending {
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	if token != "" {
+		logCaller(ctx, logging.Info, "Resumed session after request %d, replayed %d responses", sess.processed, len(sess.pending))
+	}
+
+	requests := receive(stream)
+	received := 0
 	for {
-		req, err := stream.Recv()
-		if err == io.EOF {
-			return nil
+		var req *pb.OrderRequest
+		select {
+		case <-ctx.Done():
+			if err := callEnded(stream.Context()); err != nil {
+				return err
+			}
+			return status.Error(codes.Aborted, "session resumed on another stream")
+		case r := <-requests:
+			if r.err == io.EOF {
+				// the client has every response, nothing is left to resume
+				s.sessions.end(sess)
+				return nil
+			}
+			if r.err != nil {
+				return r.err
+			}
+			req = r.req
 		}
-		if err != nil {
+
+		logCaller(ctx, logging.Debug, "Got request %d with name : %v", req.Seq, req.Name)
+		// never place an order for a caller that is already gone
+		if err := callEnded(ctx); err != nil {
 			return err
 		}
 
-		log.Printf("Got request with name : %v", req.Name)
+		sess.acknowledge(req.Ack)
+		if req.Ack > 0 && req.Seq == 0 && req.Name == "" && req.Quantity == 0 && req.Cart == nil {
+			continue
+		}
 
-		found := false
-		for i, item := range ServerOrders {
-			if strings.Contains(item, req.Name) {
-				found = true
-				res := &pb.OrderResponse{
-					Message: "Item found! Item number: " + strconv.Itoa(i+1) + ", Item name: " + item,
-				}
-				if err := stream.Send(res); err != nil {
-					return err
-				}
-			}
+		received++
+		if received > maxRequestsPerStream {
+			return quotaExceeded(fmt.Sprintf("%d requests per stream", maxRequestsPerStream), received)
 		}
-		if !found {
-			res := &pb.OrderResponse{
-				Message: "Item not found for: " + req.Name,
-			}
+
+		seq, isNew := sess.next(req.Seq)
+		if !isNew {
+			logCaller(ctx, logging.Debug, "Ignored request %d, it was handled already", seq)
+			continue
+		}
+
+		var responses []*pb.OrderResponse
+		switch {
+		case req.Cart != nil:
+			responses = []*pb.OrderResponse{s.cartCommand(ctx, &sess.cart, req)}
+		case strings.TrimSpace(req.Name) == "":
+			responses = []*pb.OrderResponse{errorResponse(req.Name, invalidField("name", "name must not be empty"))}
+		case req.Quantity < 0:
+			responses = []*pb.OrderResponse{errorResponse(req.Name, invalidField("quantity", fmt.Sprintf("quantity must not be negative, got %d", req.Quantity)))}
+		case req.Quantity > 0:
+			responses = []*pb.OrderResponse{s.orderResponse(ctx, req.Name, req.Quantity)}
+		default:
+			responses = s.lookup(req.Name)
+		}
+		for i, res := range responses {
+			res.Seq, res.Last = seq, i == len(responses)-1
+			// kept before sending, so a response lost on the way is replayed
+			sess.record(res)
 			if err := stream.Send(res); err != nil {
 				return err
 			}
 		}
 	}
 }
+
+type received struct {
+	req *pb.OrderRequest
+	err error
+}
+
+// receive reads the stream on its own goroutine, so a handler waiting for the
+// next request still notices the session being taken over
+func receive(stream pb.OrderService_GetOrderBidirectionalStreamingServer) <-chan received {
+	requests := make(chan received)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			select {
+			case requests <- received{req, err}:
+			case <-stream.Context().Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return requests
+}
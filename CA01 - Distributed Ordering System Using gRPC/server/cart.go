@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxCartLines = 100 // distinct items one cart may hold
+
+// cart is the shopping cart of a bidirectional stream's session. Nothing is
+// reserved until checkout, so quantities are only checked against the stock
+// at the time they are set.
+type cart struct {
+	lines []*pb.OrderLine // in the order they were added
+}
+
+func (c *cart) find(itemID string) int {
+	for i, line := range c.lines {
+		if line.ItemId == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+// cartCommand runs one cart command of req against c; failures only fail the
+// command, not the stream
+func (s *orderServer) cartCommand(ctx context.Context, c *cart, req *pb.OrderRequest) *pb.OrderResponse {
+	var name string
+	var err error
+	switch cmd := req.Cart.(type) {
+	case *pb.OrderRequest_AddItem:
+		name = cmd.AddItem.Name
+		err = s.addToCart(c, name, cmd.AddItem.Quantity)
+	case *pb.OrderRequest_RemoveItem:
+		name = cmd.RemoveItem.Name
+		err = s.setInCart(c, name, 0)
+	case *pb.OrderRequest_SetQuantity:
+		name = cmd.SetQuantity.Name
+		err = s.setInCart(c, name, cmd.SetQuantity.Quantity)
+	case *pb.OrderRequest_ViewCart:
+	case *pb.OrderRequest_Checkout:
+		order, err := s.checkout(ctx, c)
+		if err != nil {
+			return errorResponse("", status.Convert(err))
+		}
+		return &pb.OrderResponse{Result: &pb.OrderResponse_Order{Order: order}}
+	}
+	if err != nil {
+		return errorResponse(name, status.Convert(err))
+	}
+	return &pb.OrderResponse{Query: name, Result: &pb.OrderResponse_Cart{Cart: s.viewCart(c)}}
+}
+
+func (s *orderServer) addToCart(c *cart, name string, quantity int32) error {
+	if quantity <= 0 {
+		return invalidField("add_item.quantity", fmt.Sprintf("quantity must be positive, got %d", quantity)).Err()
+	}
+	item, err := s.cartItem(name)
+	if err != nil {
+		return err
+	}
+	total := int64(quantity)
+	if i := c.find(item.ID); i >= 0 {
+		total += int64(c.lines[i].Quantity)
+	}
+	if total > math.MaxInt32 {
+		return invalidField("add_item.quantity", fmt.Sprintf("%v would hold %d units, more than %d", name, total, math.MaxInt32)).Err()
+	}
+	return s.putInCart(c, item, int32(total))
+}
+
+// setInCart changes the quantity of an item already in the cart, adding it if
+// need be; 0 takes it out
+func (s *orderServer) setInCart(c *cart, name string, quantity int32) error {
+	if quantity < 0 {
+		return invalidField("set_quantity.quantity", fmt.Sprintf("quantity must not be negative, got %d", quantity)).Err()
+	}
+	if quantity == 0 {
+		i := -1
+		if item, ok := s.index.ByName(name); ok {
+			i = c.find(item.ID)
+		}
+		for j, line := range c.lines {
+			// an item removed from the catalog can still be taken out by name
+			if i < 0 && line.ItemName == name {
+				i = j
+			}
+		}
+		if i < 0 {
+			return status.Errorf(codes.NotFound, "%v is not in the cart", name)
+		}
+		c.lines = append(c.lines[:i:i], c.lines[i+1:]...)
+		return nil
+	}
+	item, err := s.cartItem(name)
+	if err != nil {
+		return err
+	}
+	return s.putInCart(c, item, quantity)
+}
+
+// cartItem finds the catalog item named name with its current stock
+func (s *orderServer) cartItem(name string) (catalog.Item, error) {
+	item, ok := s.index.ByName(name)
+	if !ok {
+		return item, itemNotFound(name, s.search.Suggest(name, s.index, maxSuggestions)).Err()
+	}
+	item, err := s.catalog.Get(item.ID)
+	switch {
+	case errors.Is(err, catalog.ErrNotFound):
+		return item, itemNotFound(name, nil).Err()
+	case err != nil:
+		return item, catalogUnavailable(err)
+	}
+	return item, nil
+}
+
+func (s *orderServer) putInCart(c *cart, item catalog.Item, quantity int32) error {
+	if quantity > item.Quantity {
+		return outOfStock(item, quantity).Err()
+	}
+	if i := c.find(item.ID); i >= 0 {
+		c.lines[i].Quantity = quantity
+		return nil
+	}
+	if len(c.lines) >= maxCartLines {
+		return quotaExceeded(fmt.Sprintf("%d items per cart", maxCartLines), len(c.lines)+1)
+	}
+	c.lines = append(c.lines, &pb.OrderLine{ItemId: item.ID, ItemName: item.Name, Quantity: quantity})
+	return nil
+}
+
+// viewCart lists the cart with the stock left of every item
+func (s *orderServer) viewCart(c *cart) *pb.Cart {
+	view := &pb.Cart{}
+	for _, line := range c.lines {
+		// a removed item shows as out of stock, checkout will report it
+		item, _ := s.catalog.Get(line.ItemId)
+		view.Lines = append(view.Lines, &pb.CartLine{
+			ItemId:            line.ItemId,
+			ItemName:          line.ItemName,
+			Quantity:          line.Quantity,
+			QuantityAvailable: item.Quantity,
+		})
+		view.TotalQuantity += line.Quantity
+	}
+	return view
+}
+
+// checkout reserves the stock of the whole cart at once and turns it into a
+// single order, emptying the cart; if any line cannot be filled the cart and
+// the stock stay as they were
+func (s *orderServer) checkout(ctx context.Context, c *cart) (*pb.Order, error) {
+	if len(c.lines) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "the cart is empty")
+	}
+	lines := make([]catalog.Line, len(c.lines))
+	for i, line := range c.lines {
+		lines[i] = catalog.Line{ID: line.ItemId, Quantity: line.Quantity}
+	}
+	order, err := s.placeLines(ctx, c.lines, func() error {
+		short, err := s.catalog.ReserveAll(lines)
+		switch {
+		case errors.Is(err, catalog.ErrInsufficientStock):
+			return outOfStock(short, c.lines[c.find(short.ID)].Quantity).Err()
+		case errors.Is(err, catalog.ErrNotFound):
+			// removed since it was put in the cart
+			return itemNotFound(c.lines[c.find(short.ID)].ItemName, nil).Err()
+		case err != nil:
+			return catalogUnavailable(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.lines = nil
+	logging.For(ctx).Infof("Order %v confirmed for a cart of %d items", order.OrderId, len(order.Lines))
+	return order, nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCartCommands(t *testing.T) {
+	store := catalog.NewMemory(catalog.DefaultItems())
+	client := startServer(t, store).client
+	stream, err := client.GetOrderBidirectionalStreaming(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.CloseSend()
+
+	add := func(name string, quantity int32) *pb.OrderRequest {
+		return &pb.OrderRequest{Cart: &pb.OrderRequest_AddItem{AddItem: &pb.CartAddItem{Name: name, Quantity: quantity}}}
+	}
+	set := func(name string, quantity int32) *pb.OrderRequest {
+		return &pb.OrderRequest{Cart: &pb.OrderRequest_SetQuantity{SetQuantity: &pb.CartSetQuantity{Name: name, Quantity: quantity}}}
+	}
+	remove := func(name string) *pb.OrderRequest {
+		return &pb.OrderRequest{Cart: &pb.OrderRequest_RemoveItem{RemoveItem: &pb.CartRemoveItem{Name: name}}}
+	}
+	view := &pb.OrderRequest{Cart: &pb.OrderRequest_ViewCart{ViewCart: &pb.CartView{}}}
+	checkout := &pb.OrderRequest{Cart: &pb.OrderRequest_Checkout{Checkout: &pb.CartCheckout{}}}
+
+	tests := []struct {
+		name  string
+		req   *pb.OrderRequest
+		code  codes.Code
+		total int32 // units in the cart afterwards
+	}{
+		{"empty checkout", checkout, codes.FailedPrecondition, 0},
+		{"add", add("apple", 2), codes.OK, 2},
+		{"add more", add("Apple", 1), codes.OK, 3},
+		{"add unknown", add("durian", 1), codes.NotFound, 3},
+		{"add nothing", add("kiwi", 0), codes.InvalidArgument, 3},
+		{"add beyond stock", add("apple", 198), codes.FailedPrecondition, 3},
+		{"add past int32", add("apple", math.MaxInt32), codes.InvalidArgument, 3},
+		{"set", set("mango", 40), codes.OK, 43},
+		{"set beyond stock", set("mango", 41), codes.FailedPrecondition, 43},
+		{"set negative", set("mango", -1), codes.InvalidArgument, 43},
+		{"remove", remove("mango"), codes.OK, 3},
+		{"remove twice", remove("mango"), codes.NotFound, 3},
+		{"set new", set("kiwi", 5), codes.OK, 8},
+		{"set to zero", set("kiwi", 0), codes.OK, 3},
+		{"view", view, codes.OK, 3},
+	}
+	for _, tt := range tests {
+		if err := stream.Send(tt.req); err != nil {
+			t.Fatal(err)
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code := status.FromProto(res.GetError()).Code(); code != tt.code {
+			t.Errorf("%v: got %v, want %v", tt.name, code, tt.code)
+		}
+		if tt.code == codes.OK && res.GetCart().GetTotalQuantity() != tt.total {
+			t.Errorf("%v: cart holds %d units, want %d", tt.name, res.GetCart().GetTotalQuantity(), tt.total)
+		}
+	}
+
+	stream.Send(add("pear", 4))
+	stream.Recv()
+	stream.Send(checkout)
+	res, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := res.GetOrder()
+	if order == nil || order.Status != pb.OrderStatus_CONFIRMED || len(order.Lines) != 2 || order.Quantity != 7 {
+		t.Fatalf("checkout returned %v", res)
+	}
+	if apple, _ := store.Get("item-2"); apple.Quantity != 197 {
+		t.Errorf("%d apples left, want 197", apple.Quantity)
+	}
+	stream.Send(view)
+	if res, _ := stream.Recv(); len(res.GetCart().GetLines()) != 0 {
+		t.Errorf("cart not emptied by checkout: %v", res)
+	}
+
+	// cancelling the order returns the stock of every line
+	if _, err := client.CancelOrder(context.Background(), &pb.CancelOrderRequest{OrderId: order.OrderId}); err != nil {
+		t.Fatal(err)
+	}
+	apple, _ := store.Get("item-2")
+	pear, _ := store.Get("item-8")
+	if apple.Quantity != 200 || pear.Quantity != 70 {
+		t.Errorf("after cancelling %d apples and %d pears in stock, want 200 and 70", apple.Quantity, pear.Quantity)
+	}
+}
+
+func TestCheckoutIsAllOrNothing(t *testing.T) {
+	store := catalog.NewMemory(catalog.DefaultItems())
+	client := startServer(t, store).client
+	stream, err := client.GetOrderBidirectionalStreaming(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.CloseSend()
+
+	for _, name := range []string{"banana", "mango"} {
+		stream.Send(&pb.OrderRequest{Cart: &pb.OrderRequest_SetQuantity{SetQuantity: &pb.CartSetQuantity{Name: name, Quantity: 30}}})
+		if res, err := stream.Recv(); err != nil || res.GetCart() == nil {
+			t.Fatalf("adding %v: %v, %v", name, res, err)
+		}
+	}
+	// someone else buys mango before checkout
+	if _, err := client.PlaceOrder(context.Background(), &pb.PlaceOrderRequest{ItemName: "mango", Quantity: 20}); err != nil {
+		t.Fatal(err)
+	}
+
+	stream.Send(&pb.OrderRequest{Cart: &pb.OrderRequest_Checkout{Checkout: &pb.CartCheckout{}}})
+	res, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := status.FromProto(res.GetError()).Code(); code != codes.FailedPrecondition {
+		t.Errorf("checkout with too little mango: %v, want FailedPrecondition", res)
+	}
+	if banana, _ := store.Get("item-1"); banana.Quantity != 120 {
+		t.Errorf("failed checkout took banana: %d left", banana.Quantity)
+	}
+	stream.Send(&pb.OrderRequest{Cart: &pb.OrderRequest_ViewCart{ViewCart: &pb.CartView{}}})
+	if res, _ := stream.Recv(); len(res.GetCart().GetLines()) != 2 {
+		t.Errorf("failed checkout changed the cart: %v", res)
+	}
+}
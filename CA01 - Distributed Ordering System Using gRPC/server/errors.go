@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	"github.com/m-hariri/basic-go-grpc/logging"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	errorDomain = "order_service"
+
+	maxNamesPerRequest   = 50    // names accepted by one server streaming call
+	maxRequestsPerStream = 1000  // requests accepted by one bidirectional stream
+	maxLinesPerUpload    = 10000 // order lines accepted by one upload
+	maxSuggestions       = 3     // "did you mean" names attached to NOT_FOUND errors
+)
+
+// validateNames rejects empty names and oversized requests before streaming starts
+func validateNames(field string, names []string) error {
+	if len(names) > maxNamesPerRequest {
+		return quotaExceeded(fmt.Sprintf("%d names per request", maxNamesPerRequest), len(names))
+	}
+	var violations []*errdetails.BadRequest_FieldViolation
+	for i, name := range names {
+		if strings.TrimSpace(name) == "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("%s[%d]", field, i),
+				Description: "name must not be empty",
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return badRequest(violations...).Err()
+	}
+	return nil
+}
+
+// badRequest builds an INVALID_ARGUMENT status listing the offending fields; like
+// the helpers below it falls back to the bare status if details cannot be attached
+func badRequest(violations ...*errdetails.BadRequest_FieldViolation) *status.Status {
+	st := status.New(codes.InvalidArgument, violations[0].Description)
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		return detailed
+	}
+	return st
+}
+
+func invalidField(field, description string) *status.Status {
+	return badRequest(&errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+}
+
+func quotaExceeded(limit string, got int) error {
+	st := status.Newf(codes.ResourceExhausted, "request exceeds the limit of %s (got %d)", limit, got)
+	detailed, err := st.WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{{
+			Subject:     "client",
+			Description: "limit of " + limit,
+		}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// itemNotFound describes a missing catalog item together with suggestions the
+// client may retry with
+func itemNotFound(name string, suggestions []string) *status.Status {
+	st := status.Newf(codes.NotFound, "item not found for: %v", name)
+	info := &errdetails.ErrorInfo{
+		Reason: "ITEM_NOT_FOUND",
+		Domain: errorDomain,
+	}
+	if len(suggestions) > 0 {
+		info.Metadata = map[string]string{"suggestions": strings.Join(suggestions, ",")}
+	}
+	detailed, err := st.WithDetails(info, &errdetails.ResourceInfo{
+		ResourceType: "catalog item",
+		ResourceName: name,
+		Description:  "no catalog item matches this name",
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// outOfStock reports an order larger than the stock left; the violation's
+// description carries how many units are still available
+func outOfStock(item catalog.Item, requested int32) *status.Status {
+	st := status.Newf(codes.FailedPrecondition, "not enough %v in stock: %d requested, %d available", item.Name, requested, item.Quantity)
+	detailed, err := st.WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{
+			Type:        "STOCK",
+			Subject:     item.ID,
+			Description: fmt.Sprintf("%d available", item.Quantity),
+		}},
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+func catalogUnavailable(err error) error {
+	logging.Errorf("Catalog read failed: %v", err)
+	return status.Error(codes.Unavailable, "catalog is unavailable")
+}
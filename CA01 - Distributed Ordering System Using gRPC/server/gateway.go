@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/m-hariri/basic-go-grpc/config"
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"github.com/m-hariri/basic-go-grpc/tlsutil"
+	"github.com/m-hariri/basic-go-grpc/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// gateway is the REST listener and the connection its calls go through
+type gateway struct {
+	srv  *http.Server
+	conn *grpc.ClientConn
+}
+
+// serveGateway serves OrderService as REST/JSON, with its OpenAPI document on
+// /openapi.json, on an HTTP listener at addr. The gateway calls the gRPC port
+// at target like any client, so its calls are authenticated, measured and
+// traced like every other.
+func serveGateway(addr, target string, creds credentials.TransportCredentials) (*gateway, error) {
+	conn, err := grpc.Dial(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	g, err := listenGateway(addr, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return g, nil
+}
+
+// listenGateway serves the gateway over conn at addr; drainGateway stops it
+func listenGateway(addr string, conn *grpc.ClientConn) (*gateway, error) {
+	handler, err := gatewayHandler(conn)
+	if err != nil {
+		return nil, err
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	// Addr is what the listener got, a port included if addr had none
+	srv := &http.Server{Addr: lis.Addr().String(), Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := srv.Serve(lis); err != http.ErrServerClosed {
+			logging.Errorf("Gateway listener failed: %v", err)
+		}
+	}()
+	logging.Infof("Serving the REST gateway at http://%v/v1/, its OpenAPI document at /openapi.json", srv.Addr)
+	return &gateway{srv: srv, conn: conn}, nil
+}
+
+// gatewayHandler routes the REST mapping of the http options in
+// ordering.proto to calls on conn
+func gatewayHandler(conn *grpc.ClientConn) (http.Handler, error) {
+	gateway := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader))
+	if err := pb.RegisterOrderServiceHandler(context.Background(), gateway, conn); err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gateway)
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(pb.OpenAPI)
+	})
+	return mux, nil
+}
+
+// incomingHeader passes a caller's traceparent on, so its calls join the
+// caller's trace. Authorization is passed on whatever this says.
+func incomingHeader(key string) (string, bool) {
+	if strings.EqualFold(key, tracing.TraceparentKey) {
+		return tracing.TraceparentKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeader tells callers their trace ID as Trace-Id; other response
+// headers keep the gateway's Grpc-Metadata- prefix
+func outgoingHeader(key string) (string, bool) {
+	if key == tracing.TraceIDKey {
+		return "Trace-Id", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// gatewayTarget is where the gateway reaches the gRPC listener at addr: the
+// loopback interface when it listens on every one
+func gatewayTarget(addr net.Addr) string {
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// gatewayCredentials secures the gateway's connection as the gRPC port asks
+func gatewayCredentials(cfg *config.Server) (credentials.TransportCredentials, error) {
+	if !cfg.TLS.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+	g := cfg.Gateway.TLS
+	tlsConfig, err := tlsutil.ClientConfig(g.CA, g.Cert, g.Key, g.ServerName)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
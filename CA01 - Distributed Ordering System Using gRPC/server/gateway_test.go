@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+)
+
+// startGateway serves the gateway of a harness over HTTP
+func startGateway(t *testing.T, h *harness) string {
+	t.Helper()
+	handler, err := gatewayHandler(h.gateway)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// call sends a request to the gateway and decodes its JSON answer into out
+func call(t *testing.T, method, url, body string, out interface{}) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		t.Fatalf("%v %v: %v", method, url, err)
+	}
+	return res
+}
+
+type restOrder struct {
+	OrderID  string `json:"orderId"`
+	ItemName string `json:"itemName"`
+	Quantity int32  `json:"quantity"`
+	Status   string `json:"status"`
+}
+
+type restStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func TestGatewayOrders(t *testing.T) {
+	url := startGateway(t, startServer(t, catalog.NewMemory(streamItems))) + "/v1/orders"
+
+	var placed restOrder
+	if res := call(t, "POST", url, `{"itemName": "kiwi", "quantity": 2}`, &placed); res.StatusCode != http.StatusOK {
+		t.Fatalf("POST answered %v", res.Status)
+	}
+	if placed.OrderID == "" || placed.ItemName != "kiwi" || placed.Quantity != 2 || placed.Status != "CONFIRMED" {
+		t.Errorf("placed %+v", placed)
+	}
+
+	for _, tt := range []struct {
+		method, path string
+		code         int
+		status       string
+	}{
+		{"GET", "/" + placed.OrderID, http.StatusOK, "CONFIRMED"},
+		{"DELETE", "/" + placed.OrderID, http.StatusOK, "CANCELLED"},
+		{"GET", "/" + placed.OrderID, http.StatusOK, "CANCELLED"},
+		{"POST", "/" + placed.OrderID + ":cancel", http.StatusBadRequest, ""},
+		{"GET", "/ORD-404", http.StatusNotFound, ""},
+	} {
+		var got struct {
+			restOrder
+			restStatus
+		}
+		res := call(t, tt.method, url+tt.path, "", &got)
+		if res.StatusCode != tt.code || got.Status != tt.status {
+			t.Errorf("%v %v: %v %+v, want %v %v", tt.method, tt.path, res.Status, got, tt.code, tt.status)
+		}
+	}
+
+	var rejected restStatus
+	if res := call(t, "POST", url, `{"itemName": "kiwi", "quantity": 0}`, &rejected); res.StatusCode != http.StatusBadRequest || !strings.Contains(rejected.Message, "quantity") {
+		t.Errorf("order of 0 answered %v %+v", res.Status, rejected)
+	}
+}
+
+func TestGatewayStreamsSearch(t *testing.T) {
+	url := startGateway(t, startServer(t, catalog.NewMemory(streamItems)))
+
+	for _, req := range []struct{ method, path, body string }{
+		{"GET", "/v1/search?names=apple&names=durian", ""},
+		{"POST", "/v1/search", `{"names": ["apple", "durian"]}`},
+	} {
+		r, _ := http.NewRequest(req.method, url+req.path, strings.NewReader(req.body))
+		res, err := http.DefaultClient.Do(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.TransferEncoding) == 0 || res.TransferEncoding[0] != "chunked" {
+			t.Errorf("%v %v is not chunked: %v", req.method, req.path, res.TransferEncoding)
+		}
+		var got []string
+		lines := bufio.NewScanner(res.Body)
+		for lines.Scan() {
+			var line struct {
+				Result struct {
+					Query string `json:"query"`
+					Found *struct {
+						ItemName string `json:"itemName"`
+					} `json:"found"`
+					Error *restStatus `json:"error"`
+				} `json:"result"`
+			}
+			if err := json.Unmarshal(lines.Bytes(), &line); err != nil {
+				t.Fatalf("line %q: %v", lines.Text(), err)
+			}
+			if line.Result.Found != nil {
+				got = append(got, line.Result.Found.ItemName)
+			} else if line.Result.Error != nil {
+				got = append(got, line.Result.Query+": "+line.Result.Error.Message)
+			}
+		}
+		res.Body.Close()
+		want := "apple, red apple, green apple, durian: item not found for: durian"
+		if strings.Join(got, ", ") != want {
+			t.Errorf("%v %v streamed %v, want %v", req.method, req.path, got, want)
+		}
+	}
+}
+
+func TestGatewayUploads(t *testing.T) {
+	url := startGateway(t, startServer(t, catalog.NewMemory(streamItems)))
+	body := `{"itemName": "kiwi", "quantity": 1, "reference": "r1"}
+{"itemName": "durian", "quantity": 1, "reference": "r2"}
+{"itemName": "apple", "quantity": 2, "reference": "r3"}
+`
+	var summary struct {
+		LinesAccepted int32 `json:"linesAccepted"`
+		LinesRejected int32 `json:"linesRejected"`
+		UnitsOrdered  int32 `json:"unitsOrdered"`
+	}
+	if res := call(t, "POST", url+"/v1/orders:upload", body, &summary); res.StatusCode != http.StatusOK {
+		t.Fatalf("upload answered %v", res.Status)
+	}
+	if summary.LinesAccepted != 2 || summary.LinesRejected != 1 || summary.UnitsOrdered != 3 {
+		t.Errorf("summary %+v", summary)
+	}
+}
+
+func TestGatewayTraceHeaders(t *testing.T) {
+	url := startGateway(t, startServer(t, catalog.NewMemory(streamItems)))
+	req, _ := http.NewRequest("GET", url+"/v1/orders/ORD-404", nil)
+	req.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if got := res.Header.Get("Trace-Id"); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Trace-Id %q, want the caller's trace", got)
+	}
+}
+
+func TestGatewayServesOpenAPI(t *testing.T) {
+	url := startGateway(t, startServer(t, catalog.NewMemory(streamItems)))
+	var doc struct {
+		Swagger string                     `json:"swagger"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	call(t, "GET", url+"/openapi.json", "", &doc)
+	if doc.Swagger != "2.0" {
+		t.Errorf("swagger %q", doc.Swagger)
+	}
+	for _, path := range []string{"/v1/search", "/v1/orders", "/v1/orders/{orderId}", "/v1/orders/{orderId}:cancel", "/v1/orders/{orderId}:fulfill", "/v1/orders:upload"} {
+		if _, ok := doc.Paths[path]; !ok {
+			t.Errorf("no path %v", path)
+		}
+	}
+}
+
+func TestGatewayTarget(t *testing.T) {
+	for addr, want := range map[string]string{
+		"[::]:8080":      "localhost:8080",
+		"0.0.0.0:8080":   "localhost:8080",
+		"127.0.0.1:8080": "127.0.0.1:8080",
+	} {
+		tcp, err := net.ResolveTCPAddr("tcp", addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := gatewayTarget(tcp); got != want {
+			t.Errorf("%v: got %v, want %v", addr, got, want)
+		}
+	}
+}
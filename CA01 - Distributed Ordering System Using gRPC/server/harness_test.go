@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"github.com/m-hariri/basic-go-grpc/search"
+	"github.com/m-hariri/basic-go-grpc/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// harness serves both services over an in-memory connection, backed by
+// whatever catalog the test hands in
+type harness struct {
+	conn *grpc.ClientConn
+	// gateway is dialed as serveGateway dials, without client interceptors
+	gateway  *grpc.ClientConn
+	srv      *grpc.Server
+	client   pb.OrderServiceClient
+	admin    pb.CatalogAdminServiceClient
+	shutdown chan struct{}
+	// ended receives what every streaming handler returned, in order
+	ended chan error
+}
+
+// harnessOption changes the order service before it starts serving
+type harnessOption func(*orderServer)
+
+// withPacing pauses d between the names of a server streaming call
+func withPacing(d time.Duration) harnessOption {
+	return func(s *orderServer) { s.pacing = d }
+}
+
+// withSearch replaces the default search options
+func withSearch(opts search.Options) harnessOption {
+	return func(s *orderServer) { s.search = search.NewEngine(opts) }
+}
+
+// withMetrics measures the calls and lookups into m
+func withMetrics(m *serverMetrics) harnessOption {
+	return func(s *orderServer) { s.metrics = m }
+}
+
+// withOrders keeps the orders in st
+func withOrders(st *orderStore) harnessOption {
+	return func(s *orderServer) { s.orders = st }
+}
+
+// startServer starts a harness on store, stopping it when the test ends
+func startServer(t *testing.T, store catalog.Catalog, opts ...harnessOption) *harness {
+	t.Helper()
+	stop := make(chan struct{})
+	index, err := indexCatalog(store, stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &harness{shutdown: make(chan struct{}), ended: make(chan error, 100)}
+	server := &orderServer{
+		orders:   newOrderStore(),
+		catalog:  store,
+		search:   search.NewEngine(search.Options{}),
+		index:    index,
+		sessions: newSessionStore(time.Minute),
+	}
+	for _, opt := range opts {
+		opt(server)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	// traced as the server and client are, the spans go to the exporter set
+	unary := []grpc.UnaryServerInterceptor{tracing.UnaryServerInterceptor()}
+	stream := []grpc.StreamServerInterceptor{tracing.StreamServerInterceptor(), func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		select {
+		case h.ended <- err:
+		default:
+		}
+		return err
+	}}
+	if server.metrics != nil {
+		unary = append(unary, server.metrics.grpc.UnaryServerInterceptor())
+		stream = append(stream, server.metrics.grpc.StreamServerInterceptor())
+	}
+	h.srv = grpc.NewServer(grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+	pb.RegisterOrderServiceServer(h.srv, server)
+	pb.RegisterCatalogAdminServiceServer(h.srv, &adminServer{catalog: store, shutdown: h.shutdown})
+	go h.srv.Serve(lis)
+
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) })
+	h.conn, err = grpc.Dial("bufnet", dialer,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(tracing.StreamClientInterceptor()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.gateway, err = grpc.Dial("bufnet", dialer, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.client = pb.NewOrderServiceClient(h.conn)
+	h.admin = pb.NewCatalogAdminServiceClient(h.conn)
+	t.Cleanup(func() {
+		h.conn.Close()
+		h.gateway.Close()
+		h.srv.Stop()
+		close(stop)
+	})
+	return h
+}
+
+// handlerResult waits for the next streaming handler to return
+func (h *harness) handlerResult(t *testing.T) error {
+	t.Helper()
+	select {
+	case err := <-h.ended:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream handler still running")
+		return nil
+	}
+}
+
+// responseStream is what both streaming calls have in common on the client
+type responseStream interface {
+	Recv() (*pb.OrderResponse, error)
+}
+
+// recvAll reads responses until the stream ends, returning them with the
+// error that ended it, nil for io.EOF
+func recvAll(stream responseStream) ([]*pb.OrderResponse, error) {
+	var responses []*pb.OrderResponse
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return responses, nil
+		}
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, res)
+	}
+}
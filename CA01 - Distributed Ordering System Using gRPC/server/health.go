@@ -0,0 +1,57 @@
+package main
+
+import (
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthServices get a status each; "" is the server as a whole
+var healthServices = []string{
+	"",
+	pb.OrderService_ServiceDesc.ServiceName,
+	pb.CatalogAdminService_ServiceDesc.ServiceName,
+}
+
+// watchHealth reports every service SERVING while the catalog backend is
+// ready and NOT_SERVING while it is not, checking every interval until stop
+// is closed. The first check runs before it returns.
+func watchHealth(hs *health.Server, store catalog.Catalog, interval time.Duration, stop <-chan struct{}) {
+	last := checkHealth(hs, store, healthpb.HealthCheckResponse_UNKNOWN)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				last = checkHealth(hs, store, last)
+			}
+		}
+	}()
+}
+
+func checkHealth(hs *health.Server, store catalog.Catalog, last healthpb.HealthCheckResponse_ServingStatus) healthpb.HealthCheckResponse_ServingStatus {
+	current := healthpb.HealthCheckResponse_SERVING
+	err := store.Ready()
+	if err != nil {
+		current = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	if current == last {
+		return current
+	}
+	if err != nil {
+		logging.Warnf("Catalog is not ready, reporting %v: %v", current, err)
+	} else if last != healthpb.HealthCheckResponse_UNKNOWN {
+		logging.Infof("Catalog is ready again, reporting %v", current)
+	}
+	for _, service := range healthServices {
+		hs.SetServingStatus(service, current)
+	}
+	return current
+}
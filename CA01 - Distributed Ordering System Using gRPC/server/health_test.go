@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// waitStatus polls until service reports want or the test times out
+func waitStatus(t *testing.T, hs *health.Server, service string, want healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		res, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err == nil && res.Status == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%q never became %v (last %v, %v)", service, want, res.GetStatus(), err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHealthFollowsCatalogReadiness(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.yaml")
+	if err := os.WriteFile(path, []byte("items:\n  - {id: item-1, name: banana, quantity: 1}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := catalog.NewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	hs := health.NewServer()
+	stop := make(chan struct{})
+	defer close(stop)
+	watchHealth(hs, store, 10*time.Millisecond, stop)
+	for _, service := range healthServices {
+		waitStatus(t, hs, service, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	// a file that no longer parses makes the catalog unready until it is fixed
+	os.WriteFile(path, []byte("items: [broken\n"), 0644)
+	waitStatus(t, hs, pb.OrderService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	os.WriteFile(path, []byte("items:\n  - {id: item-1, name: banana, quantity: 2}\n"), 0644)
+	waitStatus(t, hs, pb.OrderService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	store.Close()
+	waitStatus(t, hs, "", healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestReflectionListsServices(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterOrderServiceServer(srv, &orderServer{})
+	reflection.Register(srv)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, service := range res.GetListServicesResponse().GetService() {
+		if service.Name == "order_service.OrderService" {
+			return
+		}
+	}
+	t.Errorf("order_service.OrderService missing from %v", res.GetListServicesResponse())
+}
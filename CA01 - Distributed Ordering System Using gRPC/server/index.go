@@ -0,0 +1,80 @@
+package main
+
+import (
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	"github.com/m-hariri/basic-go-grpc/logging"
+	"github.com/m-hariri/basic-go-grpc/search"
+)
+
+// indexRetry is how long the index waits before taking a new snapshot
+const indexRetry = time.Second
+
+// indexCatalog builds a search index over the catalog and keeps it up to date
+// from catalog events until stop is closed. If the subscription is dropped
+// (the index fell behind) the index is rebuilt from a fresh snapshot, retried
+// until one can be read: events are only applied on top of a current snapshot.
+func indexCatalog(store catalog.Catalog, stop <-chan struct{}) (*search.Index, error) {
+	// subscribe before the snapshot so no change is missed; replaying a change
+	// the snapshot already holds leaves the index as it is
+	events, cancel := store.Subscribe()
+	items, err := store.Items()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	idx := search.NewIndex(items)
+	logging.Infof("Search index built with %d items", len(items))
+
+	go func() {
+		for {
+			if !follow(idx, events, stop) {
+				cancel()
+				return
+			}
+			cancel()
+			if events, cancel = resubscribe(idx, store, stop); events == nil {
+				return
+			}
+		}
+	}()
+	return idx, nil
+}
+
+// resubscribe subscribes again and resets the index from a new snapshot,
+// retrying every indexRetry until it succeeds; nil events means stop closed
+func resubscribe(idx *search.Index, store catalog.Catalog, stop <-chan struct{}) (<-chan catalog.Event, func()) {
+	for {
+		select {
+		case <-stop:
+			return nil, nil
+		case <-time.After(indexRetry):
+		}
+		events, cancel := store.Subscribe()
+		items, err := store.Items()
+		if err != nil {
+			cancel()
+			logging.Errorf("Search index rebuild failed, retrying in %v: %v", indexRetry, err)
+			continue
+		}
+		idx.Reset(items)
+		logging.Infof("Search index rebuilt with %d items", len(items))
+		return events, cancel
+	}
+}
+
+// follow applies events until the channel closes (true) or stop is closed (false)
+func follow(idx *search.Index, events <-chan catalog.Event, stop <-chan struct{}) bool {
+	for {
+		select {
+		case <-stop:
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return true
+			}
+			idx.Apply(event)
+		}
+	}
+}
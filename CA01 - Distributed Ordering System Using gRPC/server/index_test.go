@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+)
+
+// droppingCatalog hands out a first subscription the test can drop and fails
+// snapshots while failing is set
+type droppingCatalog struct {
+	catalog.Catalog
+	once    sync.Once
+	dropped chan catalog.Event
+	failing atomic.Bool
+}
+
+func (c *droppingCatalog) Subscribe() (<-chan catalog.Event, func()) {
+	first := false
+	c.once.Do(func() { first = true })
+	if first {
+		return c.dropped, func() {}
+	}
+	return c.Catalog.Subscribe()
+}
+
+func (c *droppingCatalog) Items() ([]catalog.Item, error) {
+	if c.failing.Load() {
+		return nil, errors.New("snapshot unavailable")
+	}
+	return c.Catalog.Items()
+}
+
+func TestIndexRetriesFailedSnapshot(t *testing.T) {
+	store := &droppingCatalog{Catalog: catalog.NewMemory(streamItems), dropped: make(chan catalog.Event)}
+	stop := make(chan struct{})
+	defer close(stop)
+	idx, err := indexCatalog(store, stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the fig is added while the index is not subscribed and cannot take a
+	// snapshot, so only a later snapshot can bring it in
+	store.failing.Store(true)
+	close(store.dropped)
+	if err := store.Add(catalog.Item{ID: "item-fig", Name: "fig", Quantity: 1}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(indexRetry + indexRetry/2)
+	store.failing.Store(false)
+
+	deadline := time.Now().Add(3 * indexRetry)
+	for {
+		if _, ok := idx.ByName("fig"); ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("index never rebuilt after the failed snapshot")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
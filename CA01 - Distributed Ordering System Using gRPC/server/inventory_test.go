@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"io"
+	"math"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Run with -race: many bidirectional streams ordering the same item at once
+// must confirm exactly as many units as were in stock
+func TestConcurrentStreamsCannotOversell(t *testing.T) {
+	const streams, ordersPerStream = 25, 20
+	store := catalog.NewMemory(catalog.DefaultItems())
+	client := startServer(t, store).client
+	apple, _ := store.Get("item-2")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var mtx sync.Mutex
+	confirmed := int32(0)
+	rejected := 0
+	var wg sync.WaitGroup
+	for i := 0; i < streams; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream, err := client.GetOrderBidirectionalStreaming(ctx)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			go func() {
+				for j := 0; j < ordersPerStream; j++ {
+					if err := stream.Send(&pb.OrderRequest{Name: "apple", Quantity: 1}); err != nil {
+						return
+					}
+				}
+				stream.CloseSend()
+			}()
+			for {
+				res, err := stream.Recv()
+				if err == io.EOF {
+					return
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mtx.Lock()
+				switch result := res.Result.(type) {
+				case *pb.OrderResponse_Order:
+					confirmed += result.Order.Quantity
+				case *pb.OrderResponse_Error:
+					if codes.Code(result.Error.Code) != codes.FailedPrecondition {
+						t.Errorf("order rejected with %v: %v", codes.Code(result.Error.Code), result.Error.Message)
+					}
+					rejected++
+				}
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if confirmed != apple.Quantity {
+		t.Errorf("confirmed %d apples with %d in stock", confirmed, apple.Quantity)
+	}
+	if want := streams*ordersPerStream - int(apple.Quantity); rejected != want {
+		t.Errorf("rejected %d orders, want %d", rejected, want)
+	}
+	if item, _ := store.Get("item-2"); item.Quantity != 0 {
+		t.Errorf("%d apples left after selling out", item.Quantity)
+	}
+}
+
+// Run with -race: renaming an item while it is ordered must leave the stock
+// the orders took, not the quantity the rename read
+func TestRenameDuringOrdersKeepsStock(t *testing.T) {
+	const workers, orders = 10, 10
+	store := catalog.NewMemory(catalog.DefaultItems())
+	h := startServer(t, store)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < orders; j++ {
+				if _, err := h.client.PlaceOrder(ctx, &pb.PlaceOrderRequest{ItemName: "apple", Quantity: 1}); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < orders; j++ {
+				_, err := h.admin.UpdateItem(ctx, &pb.UpdateItemRequest{
+					Item:       &pb.CatalogItem{Id: "item-2", Name: "apple"},
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+				})
+				if err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if item, _ := store.Get("item-2"); item.Quantity != 200-workers*orders {
+		t.Errorf("%d apples left after ordering %d of 200", item.Quantity, workers*orders)
+	}
+}
+
+func TestCancelAndRestockReturnStock(t *testing.T) {
+	store := catalog.NewMemory(catalog.DefaultItems())
+	h := startServer(t, store)
+	client, admin := h.client, h.admin
+	ctx := context.Background()
+
+	order, err := client.PlaceOrder(ctx, &pb.PlaceOrderRequest{ItemName: "mango", Quantity: 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PlaceOrder(ctx, &pb.PlaceOrderRequest{ItemName: "mango", Quantity: 1}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("ordering a sold out item: got %v, want FailedPrecondition", err)
+	}
+	if _, err := client.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: order.OrderId}); err != nil {
+		t.Fatal(err)
+	}
+	if item, _ := store.Get("item-7"); item.Quantity != 40 {
+		t.Errorf("%d mangos after cancelling, want 40", item.Quantity)
+	}
+
+	item, err := admin.RestockItem(ctx, &pb.RestockItemRequest{Id: "item-7", Quantity: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Quantity != 45 {
+		t.Errorf("%d mangos after restocking, want 45", item.Quantity)
+	}
+	if _, err := admin.RestockItem(ctx, &pb.RestockItemRequest{Id: "item-7", Quantity: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("negative restock: got %v, want InvalidArgument", err)
+	}
+	if _, err := admin.RestockItem(ctx, &pb.RestockItemRequest{Id: "item-7", Quantity: math.MaxInt32}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("restock past int32: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	store := catalog.NewMemory(catalog.DefaultItems())
+	client := startServer(t, store).client
+	ctx := context.Background()
+
+	shipped, err := client.PlaceOrder(ctx, &pb.PlaceOrderRequest{ItemName: "mango", Quantity: 10})
+	if err != nil || shipped.Status != pb.OrderStatus_CONFIRMED {
+		t.Fatalf("placed %v, %v; want a CONFIRMED order", shipped, err)
+	}
+	if shipped, err = client.FulfillOrder(ctx, &pb.FulfillOrderRequest{OrderId: shipped.OrderId}); err != nil || shipped.Status != pb.OrderStatus_FULFILLED {
+		t.Fatalf("fulfilled %v, %v", shipped, err)
+	}
+	if got, _ := client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: shipped.OrderId}); got.GetStatus() != pb.OrderStatus_FULFILLED {
+		t.Errorf("order is %v after fulfilling", got.GetStatus())
+	}
+	cancelled, err := client.PlaceOrder(ctx, &pb.PlaceOrderRequest{ItemName: "mango", Quantity: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: cancelled.OrderId}); err != nil {
+		t.Fatal(err)
+	}
+	// a placement refused for want of stock leaves its PENDING order cancelled
+	if _, err := client.PlaceOrder(ctx, &pb.PlaceOrderRequest{ItemName: "mango", Quantity: 1000}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("ordering 1000 mangos: got %v, want FailedPrecondition", err)
+	}
+	if refused, _ := client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: "ORD-000003"}); refused.GetStatus() != pb.OrderStatus_CANCELLED || refused.GetQuantity() != 1000 {
+		t.Errorf("refused order is %v", refused)
+	}
+
+	for _, tt := range []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"cancel a fulfilled order", func() error {
+			_, err := client.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: shipped.OrderId})
+			return err
+		}, codes.FailedPrecondition},
+		{"fulfil a cancelled order", func() error {
+			_, err := client.FulfillOrder(ctx, &pb.FulfillOrderRequest{OrderId: cancelled.OrderId})
+			return err
+		}, codes.FailedPrecondition},
+		{"fulfil twice", func() error {
+			_, err := client.FulfillOrder(ctx, &pb.FulfillOrderRequest{OrderId: shipped.OrderId})
+			return err
+		}, codes.FailedPrecondition},
+		{"cancel a refused order", func() error {
+			_, err := client.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: "ORD-000003"})
+			return err
+		}, codes.FailedPrecondition},
+		{"fulfil an unknown order", func() error {
+			_, err := client.FulfillOrder(ctx, &pb.FulfillOrderRequest{OrderId: "ORD-404"})
+			return err
+		}, codes.NotFound},
+	} {
+		if err := tt.call(); status.Code(err) != tt.code {
+			t.Errorf("%v: got %v, want %v", tt.name, err, tt.code)
+		}
+	}
+	// the shipped units stay taken, the cancelled ones came back
+	if item, _ := store.Get("item-7"); item.Quantity != 30 {
+		t.Errorf("%d mangos left, want 30", item.Quantity)
+	}
+}
+
+func TestOrdersOutliveRestart(t *testing.T) {
+	store := catalog.NewMemory(catalog.DefaultItems())
+	path := filepath.Join(t.TempDir(), "catalog.json.orders")
+	ctx := context.Background()
+
+	before, err := openOrderStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	placed, err := startServer(t, store, withOrders(before)).client.PlaceOrder(ctx, &pb.PlaceOrderRequest{ItemName: "mango", Quantity: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// cut off while its stock was being reserved
+	pending, err := before.create(&pb.OrderLine{ItemId: "item-7", ItemName: "mango", Quantity: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := before.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := openOrderStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer after.close()
+	client := startServer(t, store, withOrders(after)).client
+	if got, _ := client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: pending.OrderId}); got.GetStatus() != pb.OrderStatus_CANCELLED {
+		t.Errorf("pending order is %v after a restart, want CANCELLED", got.GetStatus())
+	}
+	if _, err := client.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: placed.OrderId}); err != nil {
+		t.Fatalf("cancelling an order placed before the restart: %v", err)
+	}
+	if item, _ := store.Get("item-7"); item.Quantity != 40 {
+		t.Errorf("%d mangos after cancelling, want 40", item.Quantity)
+	}
+	next, err := client.PlaceOrder(ctx, &pb.PlaceOrderRequest{ItemName: "mango", Quantity: 1})
+	if err != nil || next.OrderId != "ORD-000003" {
+		t.Errorf("next order %v, %v; want ORD-000003", next.GetOrderId(), err)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"github.com/m-hariri/basic-go-grpc/search"
+	"google.golang.org/grpc/status"
+)
+
+var matchKinds = map[search.MatchKind]pb.MatchKind{
+	search.Exact:     pb.MatchKind_EXACT,
+	search.Prefix:    pb.MatchKind_PREFIX,
+	search.Token:     pb.MatchKind_TOKEN,
+	search.Substring: pb.MatchKind_SUBSTRING,
+	search.Fuzzy:     pb.MatchKind_FUZZY,
+}
+
+// lookup builds one response per catalog item matching name, best match
+// first, or a single NOT_FOUND error response when nothing matches
+func (s *orderServer) lookup(name string) []*pb.OrderResponse {
+	var responses []*pb.OrderResponse
+	for _, result := range s.search.Search(name, s.index) {
+		responses = append(responses, &pb.OrderResponse{
+			Query: name,
+			Result: &pb.OrderResponse_Found{Found: &pb.ItemMatch{
+				ItemId:            result.Item.ID,
+				ItemName:          result.Item.Name,
+				CatalogIndex:      int32(result.Index),
+				MatchKind:         matchKinds[result.Kind],
+				QuantityAvailable: result.Item.Quantity,
+				Score:             result.Score,
+			}},
+		})
+	}
+	s.metrics.lookedUp(len(responses))
+	if len(responses) == 0 {
+		suggestions := s.search.Suggest(name, s.index, maxSuggestions)
+		responses = append(responses, errorResponse(name, itemNotFound(name, suggestions)))
+	}
+	return responses
+}
+
+// errorResponse carries a per-name failure without ending the stream
+func errorResponse(name string, st *status.Status) *pb.OrderResponse {
+	return &pb.OrderResponse{
+		Query:  name,
+		Result: &pb.OrderResponse_Error{Error: st.Proto()},
+	}
+}
+
+// orderResponse places an order from a stream request; a failure only fails
+// this request, not the stream
+func (s *orderServer) orderResponse(ctx context.Context, name string, quantity int32) *pb.OrderResponse {
+	order, err := s.placeOrder(ctx, name, quantity)
+	if err != nil {
+		return errorResponse(name, status.Convert(err))
+	}
+	return &pb.OrderResponse{Query: name, Result: &pb.OrderResponse_Order{Order: order}}
+}
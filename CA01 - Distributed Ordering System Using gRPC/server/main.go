@@ -1,36 +1,225 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	"github.com/m-hariri/basic-go-grpc/config"
+	"github.com/m-hariri/basic-go-grpc/logging"
 	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"github.com/m-hariri/basic-go-grpc/search"
+	"github.com/m-hariri/basic-go-grpc/tlsutil"
+	"github.com/m-hariri/basic-go-grpc/tracing"
 	"google.golang.org/grpc"
-)
-
-const (
-	port = ":8080"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 type orderServer struct {
 	pb.OrderServiceServer
+	orders  *orderStore
+	catalog catalog.Catalog
+	search  *search.Engine
+	index   *search.Index
+	// pacing is the pause between the names of a server streaming call
+	pacing time.Duration
+	// sessions of the bidirectional streams, kept for clients to resume
+	sessions *sessionStore
+	metrics  *serverMetrics
 }
 
-var ServerOrders = []string{"banana", "apple", "orange", "grape", "red apple",
-	"kiwi", "mango", "pear", "cherry", "green apple"}
-
 func main() {
+	cfg, err := config.LoadServer(os.Args[1:], os.LookupEnv)
+	if err == flag.ErrHelp {
+		return
+	}
+	if cfg != nil && cfg.PrintConfig {
+		config.Print(os.Stdout, cfg)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.PrintConfig {
+		return
+	}
+	level, _ := logging.ParseLevel(cfg.LogLevel)
+	logging.SetLevel(level)
+	if err := logging.SetFormat(cfg.LogFormat); err != nil {
+		log.Fatal(err)
+	}
+	// run returns rather than exits, so its deferred flushes happen
+	if err := run(cfg); err != nil {
+		logging.Errorf("Server failed: %v", err)
+		os.Exit(1)
+	}
+}
+
+// run serves until a signal stops the server, then drains it and closes the
+// catalog; it returns why it could not start or stop cleanly
+func run(cfg *config.Server) error {
+	if cfg.TraceFile != "" {
+		exporter, err := tracing.NewFileExporter(cfg.TraceFile, "order-server")
+		if err != nil {
+			return fmt.Errorf("open trace file: %w", err)
+		}
+		tracing.SetExporter(exporter)
+		defer func() {
+			if err := exporter.Close(); err != nil {
+				logging.Errorf("Spans were lost: %v", err)
+			}
+		}()
+	}
+
+	store, err := catalog.Open(cfg.Catalog.Kind, cfg.Catalog.Path)
+	if err != nil {
+		return fmt.Errorf("open catalog: %w", err)
+	}
+
+	orders, err := openOrderStore(cfg.Catalog.OrdersPath())
+	if err != nil {
+		return fmt.Errorf("open orders: %w", err)
+	}
 
-	lis, err := net.Listen("tcp", port)
+	stop := make(chan struct{})
+	index, err := indexCatalog(store, stop)
 	if err != nil {
-		log.Fatalf("Failed to start server %v", err)
+		return fmt.Errorf("index catalog: %w", err)
 	}
-	grpcServer := grpc.NewServer()
 
-	pb.RegisterOrderServiceServer(grpcServer, &orderServer{})
-	log.Printf("Server started at %v", lis.Addr())
+	lis, err := net.Listen("tcp", cfg.Listen)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	opts := []grpc.ServerOption{grpc.ConnectionTimeout(cfg.ConnectionTimeout)}
+	if cfg.TLS.Enabled() {
+		tlsConfig, err := tlsutil.ServerConfig(cfg.TLS.Cert, cfg.TLS.Key, cfg.TLS.ClientCA)
+		if err != nil {
+			return fmt.Errorf("set up TLS: %w", err)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	// traced first, so every line logged for a call carries its trace ID, and
+	// measured before authentication, so rejected calls are counted too
+	m := newServerMetrics()
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(tracing.StreamServerInterceptor()),
+		grpc.ChainUnaryInterceptor(m.grpc.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(m.grpc.StreamServerInterceptor()))
+	authenticator, err := newAuthenticator(cfg.Auth.Keys, cfg.Auth.JWTKey)
+	if err != nil {
+		return fmt.Errorf("set up authentication: %w", err)
+	}
+	if authenticator != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(authenticator.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(authenticator.StreamServerInterceptor()))
+	} else {
+		logging.Warnf("Authentication is disabled, every caller may use every RPC")
+	}
+	grpcServer := grpc.NewServer(opts...)
+	shutdown := make(chan struct{})
+
+	pb.RegisterOrderServiceServer(grpcServer, &orderServer{
+		orders:   orders,
+		catalog:  store,
+		search:   search.NewEngine(search.Options{MaxResults: cfg.MaxResults}),
+		index:    index,
+		pacing:   cfg.StreamPacing,
+		sessions: newSessionStore(cfg.SessionTTL),
+		metrics:  m,
+	})
+	pb.RegisterCatalogAdminServiceServer(grpcServer, &adminServer{catalog: store, shutdown: shutdown})
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	watchHealth(healthServer, store, cfg.HealthInterval, stop)
+	if cfg.Reflection {
+		reflection.Register(grpcServer)
+	}
+	if cfg.MetricsListen != "" {
+		metricsServer, err := serveMetrics(cfg.MetricsListen, m)
+		if err != nil {
+			return fmt.Errorf("serve metrics: %w", err)
+		}
+		// scraped until the very end, drain included
+		defer metricsServer.Close()
+	}
+	var restGateway *gateway
+	if cfg.Gateway.Listen != "" {
+		creds, err := gatewayCredentials(cfg)
+		if err != nil {
+			return fmt.Errorf("set up the gateway's TLS: %w", err)
+		}
+		if restGateway, err = serveGateway(cfg.Gateway.Listen, gatewayTarget(lis.Addr()), creds); err != nil {
+			return fmt.Errorf("serve the gateway: %w", err)
+		}
+	}
+	logging.Infof("Server started at %v with %v catalog (%v)", lis.Addr(), cfg.Catalog.Kind, transport(cfg.TLS))
+
+	signals, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	served := make(chan error, 1)
+	go func() { served <- grpcServer.Serve(lis) }()
+
+	select {
+	case err := <-served:
+		if err != nil {
+			return fmt.Errorf("serve: %w", err)
+		}
+	case <-signals.Done():
+		// a second signal gets the default behaviour and kills the process
+		stopSignals()
+		logging.Infof("Shutting down, giving in-flight calls up to %v to finish", cfg.DrainTimeout)
+		// tell load balancers to move on before the calls are drained
+		healthServer.Shutdown()
+		close(shutdown)
+		// REST requests drain first, while the gRPC calls they make still
+		// run; the gRPC drain gets what is left of the timeout
+		deadline := time.Now().Add(cfg.DrainTimeout)
+		if restGateway != nil && !drainGateway(restGateway, cfg.DrainTimeout) {
+			logging.Warnf("Drain deadline passed, remaining REST requests were cut off")
+		}
+		if drain(grpcServer, time.Until(deadline)) {
+			logging.Infof("All calls finished")
+		} else {
+			logging.Warnf("Drain deadline passed, remaining calls were cut off")
+		}
+	}
+
+	// every call is over: nothing writes to the catalog any more
+	close(stop)
+	var closeErr error
+	if err := store.Close(); err != nil {
+		closeErr = fmt.Errorf("close catalog: %w", err)
+	}
+	if err := orders.close(); err != nil {
+		closeErr = errors.Join(closeErr, fmt.Errorf("close orders: %w", err))
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	logging.Infof("Catalog closed, server stopped")
+	return nil
+}
 
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to start: %v", err)
+func transport(tls config.ServerTLS) string {
+	switch {
+	case tls.ClientCA != "":
+		return "mutual TLS"
+	case tls.Cert != "":
+		return "TLS"
+	default:
+		return "plaintext"
 	}
 }
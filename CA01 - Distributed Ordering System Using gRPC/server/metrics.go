@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/logging"
+	"github.com/m-hariri/basic-go-grpc/metrics"
+)
+
+// serverMetrics measures every call and the catalog lookups of the streaming
+// RPCs. A nil *serverMetrics measures nothing.
+type serverMetrics struct {
+	registry *metrics.Registry
+	grpc     *metrics.GRPC
+	lookups  *metrics.CounterVec
+	matches  *metrics.CounterVec
+}
+
+func newServerMetrics() *serverMetrics {
+	r := metrics.NewRegistry()
+	return &serverMetrics{
+		registry: r,
+		grpc:     metrics.NewGRPC(r),
+		lookups: r.Counter("order_catalog_lookups_total",
+			"Names looked up in the catalog by the streaming RPCs, by whether any item matched (hit or miss).", "result"),
+		matches: r.Counter("order_catalog_matches_total",
+			"Catalog items returned for the names looked up."),
+	}
+}
+
+// lookedUp counts a lookup that found matches items
+func (m *serverMetrics) lookedUp(matches int) {
+	if m == nil {
+		return
+	}
+	if matches == 0 {
+		m.lookups.Inc("miss")
+		return
+	}
+	m.lookups.Inc("hit")
+	m.matches.Add(float64(matches))
+}
+
+// serveMetrics serves the metrics on /metrics of an HTTP listener at addr,
+// apart from the gRPC port
+func serveMetrics(addr string, m *serverMetrics) (*http.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.registry.Handler())
+	// Addr is what the listener got, a port included if addr had none
+	srv := &http.Server{Addr: lis.Addr().String(), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := srv.Serve(lis); err != http.ErrServerClosed {
+			logging.Errorf("Metrics listener failed: %v", err)
+		}
+	}()
+	logging.Infof("Serving metrics at http://%v/metrics", srv.Addr)
+	return srv, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+)
+
+func TestMetricsCountCallsAndLookups(t *testing.T) {
+	m := newServerMetrics()
+	h := startServer(t, catalog.NewMemory(streamItems), withMetrics(m))
+	ctx := context.Background()
+
+	stream, err := h.client.GetOrderServerStreaming(ctx, &pb.NamesList{Names: []string{"apple", "durian", "kiwi"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := recvAll(stream); err != nil {
+		t.Fatal(err)
+	}
+	h.handlerResult(t)
+	bidi, err := h.client.GetOrderBidirectionalStreaming(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bidi.Send(&pb.OrderRequest{Name: "kiwi"})
+	bidi.Send(&pb.OrderRequest{Name: "durian"})
+	bidi.CloseSend()
+	if _, err := recvAll(bidi); err != nil {
+		t.Fatal(err)
+	}
+	h.handlerResult(t)
+	h.client.GetOrder(ctx, &pb.GetOrderRequest{OrderId: "ORD-404"})
+
+	var b strings.Builder
+	m.registry.WriteText(&b)
+	for _, want := range []string{
+		`order_catalog_lookups_total{result="hit"} 3`,
+		`order_catalog_lookups_total{result="miss"} 2`,
+		// apple matches all three apples
+		`order_catalog_matches_total 5`,
+		`grpc_server_handled_total{grpc_type="server_stream",grpc_service="order_service.OrderService",grpc_method="GetOrderServerStreaming",grpc_code="OK"} 1`,
+		`grpc_server_handled_total{grpc_type="unary",grpc_service="order_service.OrderService",grpc_method="GetOrder",grpc_code="NotFound"} 1`,
+		// three matches for apple, one for kiwi, durian's error
+		`grpc_server_msg_sent_total{grpc_type="server_stream",grpc_service="order_service.OrderService",grpc_method="GetOrderServerStreaming"} 5`,
+		`grpc_server_msg_received_total{grpc_type="bidi_stream",grpc_service="order_service.OrderService",grpc_method="GetOrderBidirectionalStreaming"} 2`,
+		`grpc_server_stream_msgs_sent_bucket{grpc_type="bidi_stream",grpc_service="order_service.OrderService",grpc_method="GetOrderBidirectionalStreaming",le="1"} 0`,
+		`grpc_server_stream_msgs_sent_bucket{grpc_type="bidi_stream",grpc_service="order_service.OrderService",grpc_method="GetOrderBidirectionalStreaming",le="2"} 1`,
+		`grpc_server_active_streams{grpc_type="bidi_stream",grpc_service="order_service.OrderService",grpc_method="GetOrderBidirectionalStreaming"} 0`,
+		`grpc_server_handling_seconds_count{grpc_type="unary",grpc_service="order_service.OrderService",grpc_method="GetOrder"} 1`,
+	} {
+		if !strings.Contains(b.String(), want+"\n") {
+			t.Errorf("metrics lack %v", want)
+		}
+	}
+	if t.Failed() {
+		t.Log(b.String())
+	}
+}
+
+func TestServeMetrics(t *testing.T) {
+	m := newServerMetrics()
+	m.lookedUp(0)
+	srv, err := serveMetrics("localhost:0", m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	res, err := http.Get("http://" + srv.Addr + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	if !strings.Contains(string(body), `order_catalog_lookups_total{result="miss"} 1`) {
+		t.Errorf("/metrics served:\n%s", body)
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	errOrderNotFound = errors.New("order not found")
+	errBadTransition = errors.New("invalid order status transition")
+	errOrderJournal  = errors.New("orders cannot be saved")
+)
+
+// allowed moves of the order state machine; orders are created PENDING and
+// settled once their stock is reserved, or could not be
+var orderTransitions = map[pb.OrderStatus][]pb.OrderStatus{
+	pb.OrderStatus_PENDING:   {pb.OrderStatus_CONFIRMED, pb.OrderStatus_CANCELLED},
+	pb.OrderStatus_CONFIRMED: {pb.OrderStatus_FULFILLED, pb.OrderStatus_CANCELLED},
+}
+
+func canTransition(from, to pb.OrderStatus) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// orderStore keeps every placed order in memory, keyed by its server-assigned
+// ID, and journals each change when it has a journal
+type orderStore struct {
+	mtx    sync.Mutex
+	orders map[string]*pb.Order
+	lastID int
+	// journal gets a line of JSON for every new order or status, nil for none
+	journal *os.File
+}
+
+func newOrderStore() *orderStore {
+	return &orderStore{orders: make(map[string]*pb.Order)}
+}
+
+// openOrderStore loads the orders journaled at path and goes on journaling
+// there, so orders placed before a restart can still be cancelled and their
+// stock returned; an empty path keeps orders in memory only. An order found
+// PENDING was cut off while its stock was being reserved and is cancelled.
+func openOrderStore(path string) (*orderStore, error) {
+	st := newOrderStore()
+	if path == "" {
+		return st, nil
+	}
+	if err := st.load(path); err != nil {
+		return nil, fmt.Errorf("load orders from %v: %w", path, err)
+	}
+	// rewrite the journal with one line per order before appending to it
+	if err := writeOrders(path, st.orders); err != nil {
+		return nil, fmt.Errorf("save orders to %v: %w", path, err)
+	}
+	journal, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	st.journal = journal
+	return st, nil
+}
+
+// load replays a journal, the last line of an order being its current state;
+// a missing journal holds no orders
+func (st *orderStore) load(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			if len(bytes.TrimSpace(line)) > 0 {
+				logging.Warnf("Orders journal %v ends with a partial line, ignored", path)
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		order := &pb.Order{}
+		if err := protojson.Unmarshal(line, order); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+		st.orders[order.OrderId] = order
+		var id int
+		if _, err := fmt.Sscanf(order.OrderId, "ORD-%d", &id); err == nil && id > st.lastID {
+			st.lastID = id
+		}
+	}
+	for _, order := range st.orders {
+		if order.Status == pb.OrderStatus_PENDING {
+			logging.Warnf("Order %v was still pending when the server stopped, cancelled", order.OrderId)
+			order.Status = pb.OrderStatus_CANCELLED
+			order.UpdatedAt = time.Now().Unix()
+		}
+	}
+	if len(st.orders) > 0 {
+		logging.Infof("Loaded %d orders from %v", len(st.orders), path)
+	}
+	return nil
+}
+
+// writeOrders saves orders in journal format, going through a temporary file
+// so a crash never leaves half a journal behind
+func writeOrders(path string, orders map[string]*pb.Order) error {
+	var data []byte
+	for _, order := range orders {
+		line, err := protojson.Marshal(order)
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// record journals the new state of an order, before the store takes it so
+// that a failed write changes nothing
+func (st *orderStore) record(order *pb.Order) error {
+	if st.journal == nil {
+		return nil
+	}
+	line, err := protojson.Marshal(order)
+	if err == nil {
+		_, err = st.journal.Write(append(line, '\n'))
+	}
+	if err != nil {
+		logging.Errorf("Could not journal order %v: %v", order.OrderId, err)
+		return fmt.Errorf("%w: %v", errOrderJournal, err)
+	}
+	return nil
+}
+
+// close stops journaling
+func (st *orderStore) close() error {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+	if st.journal == nil {
+		return nil
+	}
+	err := st.journal.Close()
+	st.journal = nil
+	return err
+}
+
+// create stores a new PENDING order of lines and returns a copy of it
+func (st *orderStore) create(lines ...*pb.OrderLine) (*pb.Order, error) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	now := time.Now().Unix()
+	order := &pb.Order{
+		OrderId:   fmt.Sprintf("ORD-%06d", st.lastID+1),
+		Lines:     lines,
+		Status:    pb.OrderStatus_PENDING,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for _, line := range lines {
+		order.Quantity += line.Quantity
+	}
+	if len(lines) == 1 {
+		order.ItemId, order.ItemName = lines[0].ItemId, lines[0].ItemName
+	}
+	if err := st.record(order); err != nil {
+		return nil, err
+	}
+	st.lastID++
+	// the store keeps its own copy, a cart goes on changing its lines
+	st.orders[order.OrderId] = copyOrder(order)
+	return copyOrder(order), nil
+}
+
+func (st *orderStore) get(orderID string) (*pb.Order, bool) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	order, ok := st.orders[orderID]
+	if !ok {
+		return nil, false
+	}
+	return copyOrder(order), true
+}
+
+// transition moves an order from one status to another if the order is in
+// the first and the state machine allows the move
+func (st *orderStore) transition(orderID string, from, to pb.OrderStatus) (*pb.Order, error) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	order, ok := st.orders[orderID]
+	if !ok {
+		return nil, errOrderNotFound
+	}
+	if order.Status != from || !canTransition(from, to) {
+		return copyOrder(order), fmt.Errorf("%w: order %s is %v and cannot become %v", errBadTransition, orderID, order.Status, to)
+	}
+	changed := copyOrder(order)
+	changed.Status = to
+	changed.UpdatedAt = time.Now().Unix()
+	if err := st.record(changed); err != nil {
+		return copyOrder(order), err
+	}
+	st.orders[orderID] = changed
+	return copyOrder(changed), nil
+}
+
+// callers get copies so they never race with later transitions
+func copyOrder(order *pb.Order) *pb.Order {
+	return proto.Clone(order).(*pb.Order)
+}
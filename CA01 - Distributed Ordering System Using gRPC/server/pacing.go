@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// callEnded returns the status error of a cancelled or expired call, or nil
+// while the caller is still waiting
+func callEnded(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
+// pause waits d between stream results, returning early with the call's
+// status error if the caller gives up or the deadline passes first
+func pause(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return callEnded(ctx)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return callEnded(ctx)
+	case <-timer.C:
+		return nil
+	}
+}
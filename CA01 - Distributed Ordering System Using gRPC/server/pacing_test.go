@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"github.com/m-hariri/basic-go-grpc/search"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordingStream is a server streaming call whose context the test controls
+type recordingStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.OrderResponse
+}
+
+func (s *recordingStream) Context() context.Context { return s.ctx }
+
+func (s *recordingStream) Send(res *pb.OrderResponse) error {
+	s.sent <- res
+	return nil
+}
+
+func pacedServer(pacing time.Duration) *orderServer {
+	items := catalog.DefaultItems()
+	return &orderServer{
+		catalog:  catalog.NewMemory(items),
+		search:   search.NewEngine(search.Options{MaxResults: 1}),
+		index:    search.NewIndex(items),
+		pacing:   pacing,
+		sessions: newSessionStore(time.Minute),
+	}
+}
+
+func TestServerStreamStopsWhenCallEnds(t *testing.T) {
+	names := &pb.NamesList{Names: []string{"kiwi", "pear", "mango", "grape"}}
+	tests := []struct {
+		name string
+		end  func(context.Context) (context.Context, context.CancelFunc)
+		code codes.Code
+	}{
+		{"cancelled", context.WithCancel, codes.Canceled},
+		{"deadline", func(ctx context.Context) (context.Context, context.CancelFunc) {
+			return context.WithTimeout(ctx, 300*time.Millisecond)
+		}, codes.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		ctx, cancel := tt.end(context.Background())
+		stream := &recordingStream{ctx: ctx, sent: make(chan *pb.OrderResponse, 10)}
+		done := make(chan error)
+		start := time.Now()
+		go func() { done <- pacedServer(time.Hour).GetOrderServerStreaming(names, stream) }()
+
+		// the first name goes out at once, then the hour long pause begins
+		if res := <-stream.sent; res.Query != "kiwi" {
+			t.Errorf("%v: first response for %q", tt.name, res.Query)
+		}
+		if tt.code == codes.Canceled {
+			cancel()
+		}
+		select {
+		case err := <-done:
+			if status.Code(err) != tt.code {
+				t.Errorf("%v: handler returned %v, want %v", tt.name, err, tt.code)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%v: handler still running %v after the call ended", tt.name, time.Since(start))
+		}
+		if len(stream.sent) != 0 {
+			t.Errorf("%v: %d responses sent after the call ended", tt.name, len(stream.sent))
+		}
+		cancel()
+	}
+}
+
+func TestServerStreamWithoutPacing(t *testing.T) {
+	stream := &recordingStream{ctx: context.Background(), sent: make(chan *pb.OrderResponse, 10)}
+	names := &pb.NamesList{Names: []string{"kiwi", "pear", "mango"}}
+	start := time.Now()
+	if err := pacedServer(0).GetOrderServerStreaming(names, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.sent) != 3 {
+		t.Errorf("got %d responses, want 3", len(stream.sent))
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("unpaced stream took %v", elapsed)
+	}
+}
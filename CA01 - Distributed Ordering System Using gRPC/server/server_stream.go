@@ -1,40 +1,31 @@
 package main
 
 import (
-	"log"
-	"strings"
-	"time"
-
+	"github.com/m-hariri/basic-go-grpc/logging"
 	pb "github.com/m-hariri/basic-go-grpc/proto"
 )
 
 func (s *orderServer) GetOrderServerStreaming(req *pb.NamesList, stream pb.OrderService_GetOrderServerStreamingServer) error {
-	log.Printf("Got request with names: %v", req.Names)
-	for _, name := range req.Names {
-		found := false
-
-		for _, item := range ServerOrders {
-			if strings.Contains(item, name) {
-				found = true
-
-				res := &pb.OrderResponse{
-					Message: "Item found: " + item,
-				}
-				if err := stream.Send(res); err != nil {
-					return err
-				}
+	ctx := stream.Context()
+	logCaller(ctx, logging.Debug, "Got request with names: %v", req.Names)
+	if err := validateNames("names", req.Names); err != nil {
+		logCaller(ctx, logging.Info, "Rejected request: %v", err)
+		return err
+	}
+	for i, name := range req.Names {
+		if i > 0 {
+			if err := pause(ctx, s.pacing); err != nil {
+				logCaller(ctx, logging.Debug, "Server stream ended after %d of %d names: %v", i, len(req.Names), err)
+				return err
 			}
 		}
-		if !found {
-			res := &pb.OrderResponse{
-				Message: "Item not found for: " + name,
-			}
+		responses := s.lookup(name)
+		for j, res := range responses {
+			res.Seq, res.Last = uint64(i+1), j == len(responses)-1
 			if err := stream.Send(res); err != nil {
 				return err
 			}
 		}
-
-		time.Sleep(2 * time.Second)
 	}
 
 	return nil
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadata keys of the bidirectional stream's session, see ordering.proto
+const (
+	sessionTokenKey = "session-token"
+	sessionSeqKey   = "session-seq"
+	sessionAckKey   = "session-ack"
+
+	// responses kept for replay until the client acknowledges them
+	maxPendingResponses = 1000
+)
+
+// session outlives the bidirectional streams attached to it, so a client that
+// reconnects neither loses responses nor places an order twice
+type session struct {
+	token string
+	owner string // caller that opened the session
+	// processed is the last request sequence number handled
+	processed uint64
+	// pending holds the responses the client has not acknowledged yet, in order
+	pending []*pb.OrderResponse
+	// forgotten is the last sequence number whose responses were dropped
+	// unacknowledged because pending was full
+	forgotten uint64
+	expires   time.Time
+	cart      cart // survives reconnects like the rest of the session
+
+	// set while a stream is attached: cancel ends it, released is closed
+	// once it let go of the session
+	cancel   context.CancelFunc
+	released chan struct{}
+}
+
+// next returns the sequence number of a request, or false if it was handled
+// already
+func (s *session) next(seq uint64) (uint64, bool) {
+	if seq == 0 {
+		seq = s.processed + 1
+	}
+	if seq <= s.processed {
+		return seq, false
+	}
+	s.processed = seq
+	return seq, true
+}
+
+// record keeps res for replay, forgetting the oldest responses beyond
+// maxPendingResponses
+func (s *session) record(res *pb.OrderResponse) {
+	s.pending = append(s.pending, res)
+	if over := len(s.pending) - maxPendingResponses; over > 0 {
+		s.forgotten = s.pending[over-1].Seq
+		s.pending = append(s.pending[:0:0], s.pending[over:]...)
+	}
+}
+
+// acknowledge drops the responses up to seq
+func (s *session) acknowledge(seq uint64) {
+	n := 0
+	for n < len(s.pending) && s.pending[n].Seq <= seq {
+		n++
+	}
+	s.pending = s.pending[n:]
+}
+
+// sessionStore holds the sessions of every client, dropping those that stay
+// detached for longer than ttl
+type sessionStore struct {
+	mtx      sync.Mutex
+	ttl      time.Duration
+	sessions map[string]*session
+	now      func() time.Time
+}
+
+func newSessionStore(ttl time.Duration) *sessionStore {
+	return &sessionStore{ttl: ttl, sessions: make(map[string]*session), now: time.Now}
+}
+
+// attach hands the session named token to a new stream, or a fresh session
+// if token is empty. A stream still attached to it is cancelled first: the
+// client only reconnects once it gave up on that one.
+func (st *sessionStore) attach(token, owner string, cancel context.CancelFunc) (*session, error) {
+	for {
+		st.mtx.Lock()
+		st.expire()
+		var sess *session
+		if token == "" {
+			sess = &session{token: newSessionToken(), owner: owner}
+			st.sessions[sess.token] = sess
+		} else if sess = st.sessions[token]; sess == nil || sess.owner != owner {
+			st.mtx.Unlock()
+			// someone else's session is reported as missing, not as forbidden
+			return nil, sessionNotFound(token)
+		}
+		if sess.released == nil {
+			sess.cancel, sess.released = cancel, make(chan struct{})
+			st.mtx.Unlock()
+			return sess, nil
+		}
+		previous, released := sess.cancel, sess.released
+		st.mtx.Unlock()
+
+		logging.Debugf("Session %v taken over by a new stream", token)
+		previous()
+		<-released
+	}
+}
+
+// release detaches sess from its stream; it expires unless resumed within
+// the ttl
+func (st *sessionStore) release(sess *session) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+	close(sess.released)
+	sess.cancel, sess.released = nil, nil
+	sess.expires = st.now().Add(st.ttl)
+}
+
+// end forgets sess for good after its client finished the stream
+func (st *sessionStore) end(sess *session) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+	delete(st.sessions, sess.token)
+}
+
+// expire drops detached sessions past their expiry; st.mtx must be held
+func (st *sessionStore) expire() {
+	now := st.now()
+	for token, sess := range st.sessions {
+		if sess.released == nil && now.After(sess.expires) {
+			logging.Debugf("Session %v expired with %d unacknowledged responses", token, len(sess.pending))
+			delete(st.sessions, token)
+		}
+	}
+}
+
+func newSessionToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// resumeRequest reads the session token and acknowledged sequence number a
+// reconnecting client sends as request metadata
+func resumeRequest(ctx context.Context) (token string, ack uint64, err error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(sessionTokenKey); len(v) > 0 {
+		token = v[0]
+	}
+	if v := md.Get(sessionAckKey); len(v) > 0 {
+		if ack, err = strconv.ParseUint(v[0], 10, 64); err != nil {
+			return "", 0, invalidField(sessionAckKey, "must be a sequence number, got "+strconv.Quote(v[0])).Err()
+		}
+	}
+	return token, ack, nil
+}
+
+func sessionNotFound(token string) error {
+	st := status.New(codes.NotFound, "session not found or expired")
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: "SESSION_NOT_FOUND",
+		Domain: errorDomain,
+	}, &errdetails.ResourceInfo{
+		ResourceType: "session",
+		ResourceName: token,
+		Description:  "sessions expire when no stream is attached for a while",
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// responsesLost reports a resume after responses the client never
+// acknowledged were dropped to bound the session's memory
+func responsesLost(ack, forgotten uint64) error {
+	st := status.Newf(codes.FailedPrecondition, "responses up to request %d were dropped unacknowledged, the session resumed from %d", forgotten, ack)
+	detailed, err := st.WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{
+			Type:        "SESSION",
+			Subject:     sessionAckKey,
+			Description: "acknowledge responses as they arrive, at most " + strconv.Itoa(maxPendingResponses) + " are kept",
+		}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
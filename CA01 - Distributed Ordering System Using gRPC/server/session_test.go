@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// openSession starts a bidirectional stream, resuming token when it is set,
+// and returns it with the session header
+func openSession(t *testing.T, ctx context.Context, client pb.OrderServiceClient, token string, ack uint64) (pb.OrderService_GetOrderBidirectionalStreamingClient, string, uint64) {
+	t.Helper()
+	if token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, sessionTokenKey, token, sessionAckKey, strconv.FormatUint(ack, 10))
+	}
+	stream, err := client.GetOrderBidirectionalStreaming(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := stream.Header()
+	if err != nil || header == nil {
+		_, err = stream.Recv()
+		t.Fatalf("no session header: %v", err)
+	}
+	seq, _ := strconv.ParseUint(header.Get(sessionSeqKey)[0], 10, 64)
+	return stream, header.Get(sessionTokenKey)[0], seq
+}
+
+func TestSessionResumesWithoutOrderingTwice(t *testing.T) {
+	store := catalog.NewMemory(catalog.DefaultItems())
+	client := startServer(t, store).client
+	kiwi, _ := store.Get("item-6")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, token, seq := openSession(t, ctx, client, "", 0)
+	if token == "" || seq != 0 {
+		t.Fatalf("new session %q starts at %d", token, seq)
+	}
+	stream.Send(&pb.OrderRequest{Seq: 1, Name: "kiwi"})
+	stream.Send(&pb.OrderRequest{Seq: 2, Name: "kiwi", Quantity: 2})
+	res, err := stream.Recv()
+	if err != nil || res.Seq != 1 || !res.Last {
+		t.Fatalf("first response %v, %v", res, err)
+	}
+	// wait for the order to be placed, then lose its response with the stream
+	for {
+		if item, _ := store.Get(kiwi.ID); item.Quantity == kiwi.Quantity-2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	stream, resumed, seq := openSession(t, context.Background(), client, token, 1)
+	if resumed != token || seq != 2 {
+		t.Fatalf("resumed %q at %d, want %q at 2", resumed, seq, token)
+	}
+	res, err = stream.Recv()
+	if err != nil || res.Seq != 2 || res.GetOrder() == nil {
+		t.Fatalf("replayed %v, %v; want the order for request 2", res, err)
+	}
+	// sending request 2 again must not place a second order
+	stream.Send(&pb.OrderRequest{Seq: 2, Name: "kiwi", Quantity: 2, Ack: 2})
+	stream.Send(&pb.OrderRequest{Seq: 3, Name: "pear"})
+	stream.CloseSend()
+	res, err = stream.Recv()
+	if err != nil || res.Seq != 3 || res.Query != "pear" {
+		t.Fatalf("after resuming got %v, %v; want request 3", res, err)
+	}
+	if item, _ := store.Get(kiwi.ID); item.Quantity != kiwi.Quantity-2 {
+		t.Errorf("%d kiwis left, want %d", item.Quantity, kiwi.Quantity-2)
+	}
+
+	// a session whose stream finished cleanly is gone
+	for {
+		if _, err := stream.Recv(); err != nil {
+			break
+		}
+	}
+	stream, err = client.GetOrderBidirectionalStreaming(metadata.AppendToOutgoingContext(context.Background(), sessionTokenKey, token))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.NotFound {
+		t.Errorf("resuming a finished session returned %v, want NotFound", err)
+	}
+}
+
+func TestSessionTakenOverByNewStream(t *testing.T) {
+	client := startServer(t, catalog.NewMemory(catalog.DefaultItems())).client
+	old, token, _ := openSession(t, context.Background(), client, "", 0)
+	old.Send(&pb.OrderRequest{Seq: 1, Name: "pear"})
+	if _, err := old.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the client gave up on the old stream, the server has not noticed yet
+	stream, _, seq := openSession(t, context.Background(), client, token, 1)
+	if seq != 1 {
+		t.Errorf("resumed at %d, want 1", seq)
+	}
+	if _, err := old.Recv(); status.Code(err) != codes.Aborted {
+		t.Errorf("old stream ended with %v, want Aborted", err)
+	}
+	stream.CloseSend()
+}
+
+func TestSessionsExpire(t *testing.T) {
+	now := time.Now()
+	st := newSessionStore(time.Minute)
+	st.now = func() time.Time { return now }
+
+	sess, err := st.attach("", "alice", func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.release(sess)
+	if _, err := st.attach(sess.token, "bob", func() {}); status.Code(err) != codes.NotFound {
+		t.Errorf("another caller resumed the session: %v", err)
+	}
+
+	now = now.Add(59 * time.Second)
+	resumed, err := st.attach(sess.token, "alice", func() {})
+	if err != nil {
+		t.Fatalf("resuming within the ttl: %v", err)
+	}
+	// attached sessions never expire
+	now = now.Add(time.Hour)
+	st.release(resumed)
+
+	now = now.Add(61 * time.Second)
+	if _, err := st.attach(sess.token, "alice", func() {}); status.Code(err) != codes.NotFound {
+		t.Errorf("resuming an expired session returned %v, want NotFound", err)
+	}
+}
+
+func TestSessionKeepsBoundedReplay(t *testing.T) {
+	sess := &session{}
+	for seq := uint64(1); seq <= maxPendingResponses+5; seq++ {
+		sess.record(&pb.OrderResponse{Seq: seq, Last: true})
+	}
+	if len(sess.pending) != maxPendingResponses || sess.forgotten != 5 {
+		t.Errorf("%d pending, forgotten up to %d", len(sess.pending), sess.forgotten)
+	}
+	sess.acknowledge(10)
+	if sess.pending[0].Seq != 11 {
+		t.Errorf("first pending response is %d after acknowledging 10", sess.pending[0].Seq)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// drain stops accepting calls and lets the running ones finish for up to
+// timeout, then closes whatever is left. It reports whether all calls
+// finished in time.
+func drain(srv *grpc.Server, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		srv.Stop()
+		<-done
+		return false
+	}
+}
+
+// drainGateway stops accepting REST requests and lets the running ones finish
+// for up to timeout, then closes whatever is left and the gateway's gRPC
+// connection. It reports whether all requests finished in time.
+func drainGateway(g *gateway, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := g.srv.Shutdown(ctx)
+	if err != nil {
+		g.srv.Close()
+	}
+	g.conn.Close()
+	return err == nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDrainLetsStreamsFinish(t *testing.T) {
+	ts := startServer(t, catalog.NewMemory(catalog.DefaultItems()))
+	stream, err := ts.client.GetOrderBidirectionalStreaming(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&pb.OrderRequest{Name: "kiwi"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	drained := make(chan bool)
+	go func() { drained <- drain(ts.srv, 5*time.Second) }()
+
+	// the open stream keeps working while the server drains
+	time.Sleep(100 * time.Millisecond)
+	if err := stream.Send(&pb.OrderRequest{Name: "pear"}); err != nil {
+		t.Fatal(err)
+	}
+	if res, err := stream.Recv(); err != nil || res.GetFound().GetItemName() != "pear" {
+		t.Fatalf("Recv during drain = %v, %v", res, err)
+	}
+	stream.CloseSend()
+	if _, err := stream.Recv(); err == nil {
+		t.Fatal("stream did not end")
+	}
+	if !<-drained {
+		t.Error("drain hit its deadline although every stream finished")
+	}
+}
+
+func TestDrainDeadlineCutsStreamsOff(t *testing.T) {
+	ts := startServer(t, catalog.NewMemory(catalog.DefaultItems()))
+	stream, err := ts.client.GetOrderBidirectionalStreaming(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&pb.OrderRequest{Name: "kiwi"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if drain(ts.srv, 200*time.Millisecond) {
+		t.Error("drain finished although a stream stayed open")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("drain took %v", elapsed)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("Recv after forced stop = %v, want Unavailable", err)
+	}
+}
+
+func TestShutdownEndsCatalogWatches(t *testing.T) {
+	ts := startServer(t, catalog.NewMemory(catalog.DefaultItems()))
+	watch, err := ts.admin.WatchCatalog(context.Background(), &pb.WatchCatalogRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// make sure the watch is running before shutting down
+	time.Sleep(100 * time.Millisecond)
+
+	close(ts.shutdown)
+	if !drain(ts.srv, 5*time.Second) {
+		t.Error("an open watch held up the drain")
+	}
+	if _, err := watch.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("watch ended with %v, want Unavailable", err)
+	}
+}
+
+// openSearch starts a paced REST search and returns once its first line arrived
+func openSearch(t *testing.T, g *gateway) *http.Response {
+	t.Helper()
+	res, err := http.Get("http://" + g.srv.Addr + "/v1/search?names=apple&names=kiwi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+func TestGatewayDrainLetsRequestsFinish(t *testing.T) {
+	h := startServer(t, catalog.NewMemory(streamItems), withPacing(300*time.Millisecond))
+	g, err := listenGateway("localhost:0", h.gateway)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := openSearch(t, g)
+
+	drained := make(chan bool)
+	go func() { drained <- drainGateway(g, 5*time.Second) }()
+
+	// new requests are turned away while the search in flight finishes
+	time.Sleep(100 * time.Millisecond)
+	if late, err := http.Get("http://" + g.srv.Addr + "/v1/orders/ORD-1"); err == nil {
+		late.Body.Close()
+		t.Errorf("request during drain answered %v", late.Status)
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil || !strings.Contains(string(body), `"kiwi"`) {
+		t.Errorf("search during drain read %s, %v", body, err)
+	}
+	if !<-drained {
+		t.Error("drain hit its deadline although every request finished")
+	}
+}
+
+func TestGatewayDrainDeadlineCutsRequestsOff(t *testing.T) {
+	h := startServer(t, catalog.NewMemory(streamItems), withPacing(5*time.Second))
+	g, err := listenGateway("localhost:0", h.gateway)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := openSearch(t, g)
+
+	start := time.Now()
+	if drainGateway(g, 200*time.Millisecond) {
+		t.Error("drain finished although a search stayed open")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("drain took %v", elapsed)
+	}
+	if body, err := io.ReadAll(res.Body); err == nil && strings.Contains(string(body), `"kiwi"`) {
+		t.Errorf("search finished after being cut off: %s", body)
+	}
+}
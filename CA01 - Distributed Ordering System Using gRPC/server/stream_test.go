@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamItems is a catalog small enough to know every match by heart
+var streamItems = []catalog.Item{
+	{ID: "a1", Name: "apple", Quantity: 5},
+	{ID: "a2", Name: "red apple", Quantity: 3},
+	{ID: "a3", Name: "green apple", Quantity: 0},
+	{ID: "k1", Name: "kiwi", Quantity: 7},
+}
+
+// streamCall is one of the streaming RPCs, to run the same cases against both
+type streamCall struct {
+	name string
+	// open starts the call and sends names
+	open func(ctx context.Context, client pb.OrderServiceClient, names []string) (responseStream, error)
+}
+
+var streamCalls = []streamCall{
+	{"server", func(ctx context.Context, client pb.OrderServiceClient, names []string) (responseStream, error) {
+		return client.GetOrderServerStreaming(ctx, &pb.NamesList{Names: names})
+	}},
+	{"bidi", func(ctx context.Context, client pb.OrderServiceClient, names []string) (responseStream, error) {
+		stream, err := client.GetOrderBidirectionalStreaming(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if err := stream.Send(&pb.OrderRequest{Name: name}); err != nil {
+				return nil, err
+			}
+		}
+		return stream, stream.CloseSend()
+	}},
+}
+
+// describe sums a response up as "seq query: item kind" or "seq query: code",
+// with a trailing . on the last response to a name
+func describe(res *pb.OrderResponse) string {
+	var s string
+	switch result := res.Result.(type) {
+	case *pb.OrderResponse_Found:
+		s = fmt.Sprintf("%d %v: %v %v", res.Seq, res.Query, result.Found.ItemName, result.Found.MatchKind)
+	case *pb.OrderResponse_Error:
+		s = fmt.Sprintf("%d %v: %v", res.Seq, res.Query, codes.Code(result.Error.Code))
+	default:
+		s = fmt.Sprintf("%d %v: %v", res.Seq, res.Query, res.Result)
+	}
+	if res.Last {
+		s += "."
+	}
+	return s
+}
+
+func TestStreamLookups(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{"exact match", []string{"kiwi"}, []string{"1 kiwi: kiwi EXACT."}},
+		{"several matches", []string{"apple"}, []string{
+			"1 apple: apple EXACT",
+			"1 apple: red apple TOKEN",
+			"1 apple: green apple TOKEN.",
+		}},
+		{"prefix", []string{"gre"}, []string{"1 gre: green apple PREFIX."}},
+		{"no match", []string{"durian"}, []string{"1 durian: NotFound."}},
+		{"mixed", []string{"durian", "red apple", "kiwi"}, []string{
+			"1 durian: NotFound.",
+			"2 red apple: red apple EXACT.",
+			"3 kiwi: kiwi EXACT.",
+		}},
+		{"repeated", []string{"kiwi", "kiwi"}, []string{"1 kiwi: kiwi EXACT.", "2 kiwi: kiwi EXACT."}},
+		{"no names", nil, nil},
+	}
+	for _, call := range streamCalls {
+		h := startServer(t, catalog.NewMemory(streamItems))
+		for _, tt := range tests {
+			t.Run(call.name+"/"+tt.name, func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				stream, err := call.open(ctx, h.client, tt.names)
+				if err != nil {
+					t.Fatal(err)
+				}
+				responses, err := recvAll(stream)
+				if err != nil {
+					t.Fatalf("stream ended with %v", err)
+				}
+				var got []string
+				for _, res := range responses {
+					got = append(got, describe(res))
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("got %q\nwant %q", got, tt.want)
+				}
+				if err := h.handlerResult(t); err != nil {
+					t.Errorf("handler returned %v after EOF", err)
+				}
+			})
+		}
+	}
+}
+
+func TestStreamsRejectEmptyNames(t *testing.T) {
+	h := startServer(t, catalog.NewMemory(streamItems))
+	names := []string{"kiwi", " "}
+
+	// a server streaming call is checked as a whole before anything is sent
+	stream, err := streamCalls[0].open(context.Background(), h.client, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if responses, err := recvAll(stream); len(responses) != 0 || status.Code(err) != codes.InvalidArgument {
+		t.Errorf("server stream: %d responses, ended with %v; want InvalidArgument", len(responses), err)
+	}
+
+	// a bidirectional stream fails only the empty request
+	stream, err = streamCalls[1].open(context.Background(), h.client, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	responses, err := recvAll(stream)
+	if err != nil || len(responses) != 2 || describe(responses[1]) != "2  : InvalidArgument." {
+		t.Errorf("bidi stream: %v, ended with %v", responses, err)
+	}
+}
+
+func TestStreamsEndWhenCancelled(t *testing.T) {
+	for _, call := range streamCalls {
+		t.Run(call.name, func(t *testing.T) {
+			// the server stream pauses before its second name for good
+			h := startServer(t, catalog.NewMemory(streamItems), withPacing(time.Hour))
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var stream responseStream
+			var err error
+			if call.name == "bidi" {
+				// keep the stream open, only the cancellation ends it
+				var bidi pb.OrderService_GetOrderBidirectionalStreamingClient
+				bidi, err = h.client.GetOrderBidirectionalStreaming(ctx)
+				if err == nil {
+					err = bidi.Send(&pb.OrderRequest{Name: "kiwi"})
+				}
+				stream = bidi
+			} else {
+				stream, err = call.open(ctx, h.client, []string{"kiwi", "apple"})
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res, err := stream.Recv(); err != nil || res.Query != "kiwi" {
+				t.Fatalf("first response %v, %v", res, err)
+			}
+
+			cancel()
+			if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+				t.Errorf("Recv after cancelling returned %v", err)
+			}
+			if err := h.handlerResult(t); status.Code(err) != codes.Canceled {
+				t.Errorf("handler returned %v, want Canceled", err)
+			}
+		})
+	}
+}
+
+func TestStreamsEndAtEOF(t *testing.T) {
+	h := startServer(t, catalog.NewMemory(streamItems))
+	stream, err := h.client.GetOrderBidirectionalStreaming(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&pb.OrderRequest{Name: "kiwi"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+	// the server keeps the stream open until the client closes its side
+	select {
+	case err := <-h.ended:
+		t.Fatalf("handler returned %v before the client closed its side", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	stream.CloseSend()
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("Recv after CloseSend returned %v, want io.EOF", err)
+	}
+	if err := h.handlerResult(t); err != nil {
+		t.Errorf("handler returned %v, want nil", err)
+	}
+	// and the stream stays ended
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("second Recv returned %v, want io.EOF", err)
+	}
+}
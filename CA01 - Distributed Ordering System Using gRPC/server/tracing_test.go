@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"github.com/m-hariri/basic-go-grpc/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// spanRecorder keeps the spans exported by client and server alike
+type spanRecorder struct {
+	mtx   sync.Mutex
+	spans map[string]*tracing.Span
+}
+
+func (r *spanRecorder) Export(s *tracing.Span) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.spans[s.Name+"/"+kindName[s.Kind]] = s
+}
+
+var kindName = map[tracing.Kind]string{tracing.Internal: "internal", tracing.Server: "server", tracing.Client: "client"}
+
+func recordSpans(t *testing.T) *spanRecorder {
+	r := &spanRecorder{spans: map[string]*tracing.Span{}}
+	tracing.SetExporter(r)
+	t.Cleanup(func() { tracing.SetExporter(nil) })
+	return r
+}
+
+func (r *spanRecorder) span(t *testing.T, name string) *tracing.Span {
+	t.Helper()
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	s, ok := r.spans[name]
+	if !ok {
+		t.Fatalf("no span %v among %v", name, r.spans)
+	}
+	return s
+}
+
+func captureLog(t *testing.T) *syncBuffer {
+	b := &syncBuffer{}
+	flags, w := log.Flags(), log.Writer()
+	log.SetFlags(0)
+	log.SetOutput(b)
+	t.Cleanup(func() {
+		log.SetFlags(flags)
+		log.SetOutput(w)
+	})
+	return b
+}
+
+// syncBuffer is written by handlers while the test reads it
+type syncBuffer struct {
+	mtx sync.Mutex
+	bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.Buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.Buffer.String()
+}
+
+func TestTraceFollowsCall(t *testing.T) {
+	spans := recordSpans(t)
+	logs := captureLog(t)
+	h := startServer(t, catalog.NewMemory(streamItems))
+
+	ctx, op := tracing.Start(context.Background(), "place order", tracing.Internal)
+	var header metadata.MD
+	if _, err := h.client.PlaceOrder(ctx, &pb.PlaceOrderRequest{ItemName: "kiwi", Quantity: 1}, grpc.Header(&header)); err != nil {
+		t.Fatal(err)
+	}
+	op.End(nil)
+
+	client := spans.span(t, "order_service.OrderService/PlaceOrder/client")
+	server := spans.span(t, "order_service.OrderService/PlaceOrder/server")
+	if client.TraceID != op.TraceID || client.Parent != op.SpanID {
+		t.Errorf("client span %+v is not a child of %+v", client.SpanContext, op.SpanContext)
+	}
+	if server.TraceID != op.TraceID || server.Parent != client.SpanID {
+		t.Errorf("server span %+v is not a child of client span %+v", server.SpanContext, client.SpanContext)
+	}
+	if got := header.Get(tracing.TraceIDKey); len(got) != 1 || got[0] != op.TraceID.String() {
+		t.Errorf("trace-id header %v, want %v", got, op.TraceID)
+	}
+	if want := "trace_id=" + op.TraceID.String(); !strings.Contains(logs.String(), "confirmed, 6 kiwi left "+want) {
+		t.Errorf("log lacks %v:\n%v", want, logs)
+	}
+}
+
+func TestStreamLinesCarrySession(t *testing.T) {
+	logs := captureLog(t)
+	logging.SetLevel(logging.Debug)
+	defer logging.SetLevel(logging.Info)
+	h := startServer(t, catalog.NewMemory(streamItems))
+
+	ctx, op := tracing.Start(context.Background(), "stream", tracing.Internal)
+	defer op.End(nil)
+	stream, err := h.client.GetOrderBidirectionalStreaming(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Send(&pb.OrderRequest{Name: "kiwi"})
+	stream.CloseSend()
+	if _, err := recvAll(stream); err != nil {
+		t.Fatal(err)
+	}
+	h.handlerResult(t)
+	header, _ := stream.Header()
+	line := "Got request 0 with name : kiwi trace_id=" + op.TraceID.String() + " session=" + header.Get(sessionTokenKey)[0]
+	if !strings.Contains(logs.String(), line) {
+		t.Errorf("log lacks %q:\n%v", line, logs)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *orderServer) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.Order, error) {
+	logCaller(ctx, logging.Debug, "Got order for %d x %v", req.Quantity, req.ItemName)
+	if req.ItemName == "" {
+		return nil, invalidField("item_name", "item name is required").Err()
+	}
+	if req.Quantity <= 0 {
+		return nil, invalidField("quantity", fmt.Sprintf("quantity must be positive, got %d", req.Quantity)).Err()
+	}
+	return s.placeOrder(ctx, req.ItemName, req.Quantity)
+}
+
+// placeOrder takes the stock out of the catalog before the order is
+// confirmed, so concurrent orders can never confirm more units than there are
+func (s *orderServer) placeOrder(ctx context.Context, itemName string, quantity int32) (*pb.Order, error) {
+	item, ok := s.index.ByName(itemName)
+	if !ok {
+		return nil, itemNotFound(itemName, s.search.Suggest(itemName, s.index, maxSuggestions)).Err()
+	}
+	order, err := s.placeLines(ctx, []*pb.OrderLine{{ItemId: item.ID, ItemName: item.Name, Quantity: quantity}}, func() error {
+		var err error
+		item, err = s.catalog.Reserve(item.ID, quantity)
+		switch {
+		case errors.Is(err, catalog.ErrInsufficientStock):
+			return outOfStock(item, quantity).Err()
+		case errors.Is(err, catalog.ErrNotFound):
+			// removed since it was indexed
+			return itemNotFound(itemName, nil).Err()
+		case err != nil:
+			return catalogUnavailable(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	logging.For(ctx).Infof("Order %v confirmed, %d %v left", order.OrderId, item.Quantity, item.Name)
+	return order, nil
+}
+
+// placeLines creates a PENDING order of lines and confirms it once reserve
+// took their stock; if reserve fails the order is cancelled and its error
+// returned
+func (s *orderServer) placeLines(ctx context.Context, lines []*pb.OrderLine, reserve func() error) (*pb.Order, error) {
+	order, err := s.orders.create(lines...)
+	if err != nil {
+		return nil, orderError(err)
+	}
+	if err := reserve(); err != nil {
+		// should this fail too, the order stays PENDING until a restart cancels it
+		if _, err := s.orders.transition(order.OrderId, pb.OrderStatus_PENDING, pb.OrderStatus_CANCELLED); err != nil {
+			logging.For(ctx).Errorf("Could not cancel refused order %v: %v", order.OrderId, err)
+		}
+		return nil, err
+	}
+	confirmed, err := s.orders.transition(order.OrderId, pb.OrderStatus_PENDING, pb.OrderStatus_CONFIRMED)
+	if err != nil {
+		// an order that cannot be confirmed must not hold stock
+		for _, line := range lines {
+			if _, err := s.catalog.Restock(line.ItemId, line.Quantity); err != nil {
+				logging.For(ctx).Errorf("Could not return %d %v of order %v: %v", line.Quantity, line.ItemName, order.OrderId, err)
+			}
+		}
+		return nil, orderError(err)
+	}
+	return confirmed, nil
+}
+
+func (s *orderServer) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	order, ok := s.orders.get(req.OrderId)
+	if !ok {
+		return nil, orderError(errOrderNotFound)
+	}
+	return order, nil
+}
+
+func (s *orderServer) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
+	logCaller(ctx, logging.Info, "Got cancel request for order %v", req.OrderId)
+	order, err := s.cancelOrder(ctx, req.OrderId)
+	if err != nil {
+		return nil, orderError(err)
+	}
+	return order, nil
+}
+
+// cancelOrder cancels a confirmed order and puts its stock back
+func (s *orderServer) cancelOrder(ctx context.Context, orderID string) (*pb.Order, error) {
+	order, err := s.orders.transition(orderID, pb.OrderStatus_CONFIRMED, pb.OrderStatus_CANCELLED)
+	if err != nil {
+		return nil, err
+	}
+	// every order holds its stock from the moment it is placed
+	for _, line := range order.Lines {
+		if _, err := s.catalog.Restock(line.ItemId, line.Quantity); err != nil {
+			logging.For(ctx).Errorf("Could not return %d %v of order %v: %v", line.Quantity, line.ItemName, order.OrderId, err)
+		}
+	}
+	return order, nil
+}
+
+func (s *orderServer) FulfillOrder(ctx context.Context, req *pb.FulfillOrderRequest) (*pb.Order, error) {
+	logCaller(ctx, logging.Info, "Got fulfil request for order %v", req.OrderId)
+	order, err := s.orders.transition(req.OrderId, pb.OrderStatus_CONFIRMED, pb.OrderStatus_FULFILLED)
+	if err != nil {
+		return nil, orderError(err)
+	}
+	return order, nil
+}
+
+// orderError maps order store errors to gRPC status errors
+func orderError(err error) error {
+	switch {
+	case errors.Is(err, errOrderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errBadTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, errOrderJournal):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/m-hariri/basic-go-grpc/logging"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/status"
+)
+
+// UploadOrders places the lines only once the client closed the upload: an
+// upload over the line limit or cancelled before its end orders nothing. If
+// the call ends before the summary is sent, the client never learns the order
+// IDs, so the orders placed are cancelled again.
+func (s *orderServer) UploadOrders(stream pb.OrderService_UploadOrdersServer) error {
+	ctx := stream.Context()
+	var lines []*pb.UploadOrderLine
+	for {
+		line, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(lines) == maxLinesPerUpload {
+			return quotaExceeded(fmt.Sprintf("%d lines per upload", maxLinesPerUpload), len(lines)+1)
+		}
+		lines = append(lines, line)
+	}
+
+	summary := &pb.UploadSummary{}
+	for _, line := range lines {
+		if err := callEnded(ctx); err != nil {
+			s.cancelUpload(ctx, summary)
+			return err
+		}
+		summary.LinesReceived++
+		order, err := s.uploadLine(ctx, line)
+		if err != nil {
+			summary.LinesRejected++
+			summary.Rejected = append(summary.Rejected, &pb.RejectedLine{
+				Line:      summary.LinesReceived,
+				Reference: line.Reference,
+				Error:     status.Convert(err).Proto(),
+			})
+			continue
+		}
+		summary.LinesAccepted++
+		summary.UnitsOrdered += order.Quantity
+		summary.Accepted = append(summary.Accepted, &pb.AcceptedLine{
+			Line:      summary.LinesReceived,
+			Reference: line.Reference,
+			Order:     order,
+		})
+	}
+	if err := stream.SendAndClose(summary); err != nil {
+		s.cancelUpload(ctx, summary)
+		return err
+	}
+	logCaller(ctx, logging.Info, "Upload of %d lines: %d accepted for %d units, %d rejected",
+		summary.LinesReceived, summary.LinesAccepted, summary.UnitsOrdered, summary.LinesRejected)
+	return nil
+}
+
+// cancelUpload cancels the orders of an upload whose summary cannot be sent
+func (s *orderServer) cancelUpload(ctx context.Context, summary *pb.UploadSummary) {
+	for _, line := range summary.Accepted {
+		if _, err := s.cancelOrder(ctx, line.Order.OrderId); err != nil {
+			logging.For(ctx).Errorf("Could not cancel order %v of a failed upload: %v", line.Order.OrderId, err)
+		}
+	}
+	logging.For(ctx).Warnf("Upload ended before its summary was sent, %d orders cancelled", len(summary.Accepted))
+}
+
+func (s *orderServer) uploadLine(ctx context.Context, line *pb.UploadOrderLine) (*pb.Order, error) {
+	if strings.TrimSpace(line.ItemName) == "" {
+		return nil, invalidField("item_name", "item name is required").Err()
+	}
+	if line.Quantity <= 0 {
+		return nil, invalidField("quantity", fmt.Sprintf("quantity must be positive, got %d", line.Quantity)).Err()
+	}
+	return s.placeOrder(ctx, line.ItemName, line.Quantity)
+}
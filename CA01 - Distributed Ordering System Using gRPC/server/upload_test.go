@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/m-hariri/basic-go-grpc/catalog"
+	pb "github.com/m-hariri/basic-go-grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUploadOrdersSummary(t *testing.T) {
+	store := catalog.NewMemory(catalog.DefaultItems())
+	client := startServer(t, store).client
+	stream, err := client.UploadOrders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := []struct {
+		line *pb.UploadOrderLine
+		code codes.Code
+	}{
+		{&pb.UploadOrderLine{ItemName: "apple", Quantity: 3, Reference: "a"}, codes.OK},
+		{&pb.UploadOrderLine{ItemName: "kiwi", Quantity: 0, Reference: "b"}, codes.InvalidArgument},
+		{&pb.UploadOrderLine{ItemName: "durian", Quantity: 1, Reference: "c"}, codes.NotFound},
+		{&pb.UploadOrderLine{ItemName: "mango", Quantity: 41, Reference: "d"}, codes.FailedPrecondition},
+		{&pb.UploadOrderLine{ItemName: "", Quantity: 1, Reference: "e"}, codes.InvalidArgument},
+		{&pb.UploadOrderLine{ItemName: "mango", Quantity: 40, Reference: "f"}, codes.OK},
+	}
+	for _, l := range lines {
+		if err := stream.Send(l.line); err != nil {
+			t.Fatal(err)
+		}
+	}
+	summary, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.LinesReceived != 6 || summary.LinesAccepted != 2 || summary.LinesRejected != 4 || summary.UnitsOrdered != 43 {
+		t.Errorf("summary counts %d received, %d accepted, %d rejected, %d units; want 6, 2, 4, 43",
+			summary.LinesReceived, summary.LinesAccepted, summary.LinesRejected, summary.UnitsOrdered)
+	}
+	accepted := map[int32]bool{}
+	for _, a := range summary.Accepted {
+		accepted[a.Line] = true
+		if a.Reference != lines[a.Line-1].line.Reference || a.Order.Status != pb.OrderStatus_CONFIRMED {
+			t.Errorf("accepted line %d: %v", a.Line, a)
+		}
+	}
+	for _, r := range summary.Rejected {
+		want := lines[r.Line-1]
+		if code := status.FromProto(r.Error).Code(); code != want.code || r.Reference != want.line.Reference {
+			t.Errorf("rejected line %d (%v) with %v, want %v", r.Line, r.Reference, code, want.code)
+		}
+	}
+	for i, l := range lines {
+		if (l.code == codes.OK) != accepted[int32(i+1)] {
+			t.Errorf("line %d accepted = %v, want %v", i+1, accepted[int32(i+1)], l.code == codes.OK)
+		}
+	}
+	if mango, _ := store.Get("item-7"); mango.Quantity != 0 {
+		t.Errorf("%d mango left, want 0", mango.Quantity)
+	}
+}
+
+func TestUploadOrdersOverLimitOrdersNothing(t *testing.T) {
+	store := catalog.NewMemory(catalog.DefaultItems())
+	client := startServer(t, store).client
+	stream, err := client.UploadOrders(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i <= maxLinesPerUpload; i++ {
+		if err := stream.Send(&pb.UploadOrderLine{ItemName: "mango", Quantity: 1}); err != nil {
+			break // the server already refused the upload
+		}
+	}
+	if _, err := stream.CloseAndRecv(); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("upload of %d lines ended with %v, want ResourceExhausted", maxLinesPerUpload+1, err)
+	}
+	if mango, _ := store.Get("item-7"); mango.Quantity != 40 {
+		t.Errorf("%d mango left, want all 40", mango.Quantity)
+	}
+}
+
+func TestUploadOrdersCancelledOrdersNothing(t *testing.T) {
+	store := catalog.NewMemory(catalog.DefaultItems())
+	h := startServer(t, store)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := h.client.UploadOrders(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := stream.Send(&pb.UploadOrderLine{ItemName: "mango", Quantity: 5}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cancel()
+	if err := h.handlerResult(t); status.Code(err) != codes.Canceled {
+		t.Errorf("upload ended with %v, want Canceled", err)
+	}
+	if mango, _ := store.Get("item-7"); mango.Quantity != 40 {
+		t.Errorf("%d mango left, want all 40", mango.Quantity)
+	}
+}
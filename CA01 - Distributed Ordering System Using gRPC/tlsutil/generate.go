@@ -0,0 +1,144 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// files written by GenerateDev, relative to its output directory
+const (
+	CAFile         = "ca.pem"
+	CAKeyFile      = "ca-key.pem"
+	ServerCertFile = "server.pem"
+	ServerKeyFile  = "server-key.pem"
+	ClientCertFile = "client.pem"
+	ClientKeyFile  = "client-key.pem"
+)
+
+// DevOptions describes the certificates GenerateDev issues
+type DevOptions struct {
+	// Hosts are the DNS names and IP addresses the server certificate is
+	// valid for; localhost, 127.0.0.1 and ::1 are always included
+	Hosts []string
+	// ClientName is the common name of the client certificate
+	ClientName string
+	Validity   time.Duration
+}
+
+// GenerateDev writes a self-signed CA plus a server and a client certificate
+// signed by it into dir. They are meant for development and tests only.
+func GenerateDev(dir string, opts DevOptions) error {
+	if opts.ClientName == "" {
+		opts.ClientName = "dev-client"
+	}
+	if opts.Validity <= 0 {
+		opts.Validity = 365 * 24 * time.Hour
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	notBefore := time.Now().Add(-time.Hour) // tolerate small clock skew
+	notAfter := notBefore.Add(opts.Validity)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	caTemplate := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "order service dev CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := issue(caTemplate, caTemplate, caKey, caKey)
+	if err != nil {
+		return err
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return err
+	}
+	if err := writePair(dir, CAFile, CAKeyFile, caDER, caKey); err != nil {
+		return err
+	}
+
+	server := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "localhost"},
+		NotBefore:   notBefore,
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range append([]string{"localhost", "127.0.0.1", "::1"}, opts.Hosts...) {
+		if ip := net.ParseIP(host); ip != nil {
+			server.IPAddresses = append(server.IPAddresses, ip)
+		} else {
+			server.DNSNames = append(server.DNSNames, host)
+		}
+	}
+	if err := issuePair(dir, ServerCertFile, ServerKeyFile, server, ca, caKey); err != nil {
+		return err
+	}
+
+	client := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: opts.ClientName},
+		NotBefore:   notBefore,
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	return issuePair(dir, ClientCertFile, ClientKeyFile, client, ca, caKey)
+}
+
+// issuePair creates a key for template and signs it with the CA
+func issuePair(dir, certFile, keyFile string, template, ca *x509.Certificate, caKey *ecdsa.PrivateKey) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	der, err := issue(template, ca, key, caKey)
+	if err != nil {
+		return err
+	}
+	return writePair(dir, certFile, keyFile, der, key)
+}
+
+func issue(template, parent *x509.Certificate, key, parentKey *ecdsa.PrivateKey) ([]byte, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = serial
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, fmt.Errorf("tls: sign %v: %w", template.Subject.CommonName, err)
+	}
+	return der, nil
+}
+
+func writePair(dir, certFile, keyFile string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := writePEM(filepath.Join(dir, certFile), "CERTIFICATE", der, 0644); err != nil {
+		return err
+	}
+	return writePEM(filepath.Join(dir, keyFile), "PRIVATE KEY", keyDER, 0600)
+}
+
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), perm)
+}
@@ -0,0 +1,92 @@
+package tlsutil
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// serve starts a gRPC server with the given TLS config and returns its address
+func serve(t *testing.T, certs, clientCA string) string {
+	config, err := ServerConfig(filepath.Join(certs, ServerCertFile), filepath.Join(certs, ServerKeyFile), clientCA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+func check(addr, caFile, certFile, keyFile string) error {
+	config, err := ClientConfig(caFile, certFile, keyFile, "localhost")
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestDevCertificates(t *testing.T) {
+	certs, other := t.TempDir(), t.TempDir()
+	for _, dir := range []string{certs, other} {
+		if err := GenerateDev(dir, DevOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ca := filepath.Join(certs, CAFile)
+	clientCert, clientKey := filepath.Join(certs, ClientCertFile), filepath.Join(certs, ClientKeyFile)
+	otherCert, otherKey := filepath.Join(other, ClientCertFile), filepath.Join(other, ClientKeyFile)
+
+	tlsAddr := serve(t, certs, "")
+	mtlsAddr := serve(t, certs, ca)
+	tests := []struct {
+		name              string
+		addr, ca          string
+		certFile, keyFile string
+		ok                bool
+	}{
+		{"tls", tlsAddr, ca, "", "", true},
+		{"tls with untrusted server", tlsAddr, filepath.Join(other, CAFile), "", "", false},
+		{"mtls", mtlsAddr, ca, clientCert, clientKey, true},
+		{"mtls without client cert", mtlsAddr, ca, "", "", false},
+		{"mtls with client cert from another CA", mtlsAddr, ca, otherCert, otherKey, false},
+	}
+	for _, tt := range tests {
+		err := check(tt.addr, tt.ca, tt.certFile, tt.keyFile)
+		if ok := err == nil; ok != tt.ok {
+			t.Errorf("%v: got error %v, want success %v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	if _, err := ServerConfig("", "", ""); err == nil {
+		t.Error("ServerConfig without a certificate succeeded")
+	}
+	if _, err := ClientConfig("", "client.pem", "", ""); err == nil {
+		t.Error("ClientConfig with a certificate but no key succeeded")
+	}
+	if _, err := ClientConfig(filepath.Join(t.TempDir(), "missing.pem"), "", "", ""); err == nil {
+		t.Error("ClientConfig with a missing CA bundle succeeded")
+	}
+}
@@ -0,0 +1,174 @@
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// scopeName is the instrumentation scope the spans are recorded under
+const scopeName = "github.com/m-hariri/basic-go-grpc/tracing"
+
+// FileExporter writes each span as one line of OTLP JSON, the format of an
+// OpenTelemetry collector's file exporter, so the collector's file receiver
+// or any OTLP tool can load it
+type FileExporter struct {
+	service string
+	mtx     sync.Mutex
+	w       io.Writer
+	closer  io.Closer
+	err     error
+}
+
+// NewFileExporter appends the spans of service to the file at path
+func NewFileExporter(path, service string) (*FileExporter, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	e := NewWriterExporter(f, service)
+	e.closer = f
+	return e, nil
+}
+
+// NewWriterExporter writes the spans of service to w
+func NewWriterExporter(w io.Writer, service string) *FileExporter {
+	return &FileExporter{service: service, w: w}
+}
+
+func (e *FileExporter) Export(s *Span) {
+	line, err := json.Marshal(e.request(s))
+	if err == nil {
+		line = append(line, '\n')
+	}
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if err == nil && e.err == nil {
+		_, err = e.w.Write(line)
+	}
+	if err != nil && e.err == nil {
+		e.err = fmt.Errorf("exporting span %v: %w", s.Name, err)
+	}
+}
+
+// Close closes the file and returns the first error met while exporting
+func (e *FileExporter) Close() error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.closer != nil {
+		if err := e.closer.Close(); err != nil && e.err == nil {
+			e.err = err
+		}
+		e.closer = nil
+	}
+	return e.err
+}
+
+// The OTLP JSON encoding: IDs are hex, 64-bit integers are strings
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              Kind            `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+}
+
+// status codes of OTLP: unset, ok and error
+const (
+	statusUnset = 0
+	statusError = 2
+)
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (e *FileExporter) request(s *Span) otlpRequest {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	span := otlpSpan{
+		TraceID:           s.TraceID.String(),
+		SpanID:            s.SpanID.String(),
+		Name:              s.Name,
+		Kind:              s.Kind,
+		StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Status:            otlpStatus{Code: statusUnset},
+	}
+	if s.Parent.IsValid() {
+		span.ParentSpanID = s.Parent.String()
+	}
+	for _, a := range s.attributes {
+		span.Attributes = append(span.Attributes, attribute(a.Key, a.Value))
+	}
+	if s.err != nil {
+		span.Status = otlpStatus{Code: statusError, Message: s.err.Error()}
+	}
+	return otlpRequest{[]otlpResourceSpans{{
+		Resource:   otlpResource{[]otlpAttribute{attribute("service.name", e.service)}},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{scopeName}, Spans: []otlpSpan{span}}},
+	}}}
+}
+
+func attribute(key string, value interface{}) otlpAttribute {
+	var v otlpValue
+	switch value := value.(type) {
+	case bool:
+		v.BoolValue = &value
+	case int:
+		s := strconv.Itoa(value)
+		v.IntValue = &s
+	case int32:
+		s := strconv.FormatInt(int64(value), 10)
+		v.IntValue = &s
+	case int64:
+		s := strconv.FormatInt(value, 10)
+		v.IntValue = &s
+	default:
+		s := fmt.Sprint(value)
+		v.StringValue = &s
+	}
+	return otlpAttribute{key, v}
+}
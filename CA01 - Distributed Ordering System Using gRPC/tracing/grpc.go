@@ -0,0 +1,140 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// TraceparentKey is the metadata key carrying the caller's span
+	TraceparentKey = "traceparent"
+	// TraceIDKey is the response header telling the caller its trace ID,
+	// handy for callers that sent none
+	TraceIDKey = "trace-id"
+)
+
+// UnaryServerInterceptor runs each call in a server span, a child of the
+// caller's span if it sent a traceparent and a new trace otherwise. Chain it
+// first, so the trace ID is on the lines logged by the interceptors after it.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startServer(ctx, info.FullMethod)
+		grpc.SetHeader(ctx, metadata.Pairs(TraceIDKey, span.TraceID.String()))
+		res, err := handler(ctx, req)
+		endRPC(span, err)
+		return res, err
+	}
+}
+
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startServer(ss.Context(), info.FullMethod)
+		ss.SetHeader(metadata.Pairs(TraceIDKey, span.TraceID.String()))
+		err := handler(srv, &serverStream{ss, ctx})
+		endRPC(span, err)
+		return err
+	}
+}
+
+func startServer(ctx context.Context, method string) (context.Context, *Span) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(TraceparentKey); len(v) > 0 {
+			if sc, err := ParseTraceparent(v[0]); err == nil {
+				ctx = WithRemote(ctx, sc)
+			}
+		}
+	}
+	return startRPC(ctx, method, Server)
+}
+
+// serverStream hands the handler the context holding the span
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryClientInterceptor runs each call, every retry of it, in a client span
+// and sends it along as the traceparent
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := startClient(ctx, method)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		endRPC(span, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor ends the client span of a stream when receiving
+// fails or, if the server sends a single message, once it got it
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := startClient(ctx, method)
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			endRPC(span, err)
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, span: span, single: !desc.ServerStreams}, nil
+	}
+}
+
+func startClient(ctx context.Context, method string) (context.Context, *Span) {
+	ctx, span := startRPC(ctx, method, Client)
+	// set, not appended: the context may come from an earlier attempt
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(TraceparentKey, span.Traceparent())
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	span   *Span
+	single bool
+	once   sync.Once
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == io.EOF:
+		s.end(nil)
+	case err != nil:
+		s.end(err)
+	case s.single:
+		s.end(nil)
+	}
+	return err
+}
+
+func (s *clientStream) end(err error) {
+	s.once.Do(func() { endRPC(s.span, err) })
+}
+
+// startRPC names the span after the method, as OpenTelemetry's semantic
+// conventions for RPC do, "package.Service/Method"
+func startRPC(ctx context.Context, method string, kind Kind) (context.Context, *Span) {
+	name := strings.TrimPrefix(method, "/")
+	ctx, span := Start(ctx, name, kind)
+	service, rpc := name, ""
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		service, rpc = name[:i], name[i+1:]
+	}
+	span.SetAttributes("rpc.system", "grpc", "rpc.service", service, "rpc.method", rpc)
+	return ctx, span
+}
+
+func endRPC(span *Span, err error) {
+	span.SetAttributes("rpc.grpc.status_code", int(status.Code(err)))
+	span.End(err)
+}
@@ -0,0 +1,206 @@
+// Package tracing follows a request across the client and the server: a trace
+// ID is made for each request and passed along in the W3C traceparent
+// metadata, so the lines logged and the spans recorded on both sides can be
+// tied together. Spans are exported to a file when SetExporter was called and
+// are dropped otherwise.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/m-hariri/basic-go-grpc/logging"
+)
+
+// TraceID names a whole request, every span of it included
+type TraceID [16]byte
+
+// SpanID names one operation of a trace
+type SpanID [8]byte
+
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+func (id SpanID) String() string  { return hex.EncodeToString(id[:]) }
+
+func (id TraceID) IsValid() bool { return id != TraceID{} }
+func (id SpanID) IsValid() bool  { return id != SpanID{} }
+
+// SpanContext is what crosses process boundaries: the trace and the span the
+// next span is a child of
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent formats sc as a W3C traceparent value, always sampled
+func (sc SpanContext) Traceparent() string {
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-01"
+}
+
+var errTraceparent = errors.New("malformed traceparent")
+
+// ParseTraceparent reads a W3C traceparent value. Versions after 00 are read
+// the same way, as the specification asks, as long as they start alike.
+func ParseTraceparent(s string) (SpanContext, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) < 4 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}, errTraceparent
+	}
+	var sc SpanContext
+	var version, flags [1]byte
+	for _, f := range []struct {
+		dst []byte
+		src string
+	}{{version[:], parts[0]}, {sc.TraceID[:], parts[1]}, {sc.SpanID[:], parts[2]}, {flags[:], parts[3]}} {
+		if len(f.src) != 2*len(f.dst) || strings.ToLower(f.src) != f.src {
+			return SpanContext{}, errTraceparent
+		}
+		if _, err := hex.Decode(f.dst, []byte(f.src)); err != nil {
+			return SpanContext{}, errTraceparent
+		}
+	}
+	if !sc.IsValid() {
+		return SpanContext{}, errTraceparent
+	}
+	return sc, nil
+}
+
+// Kind tells what side of a call a span is, numbered as in OTLP
+type Kind int
+
+const (
+	Internal Kind = 1
+	Server   Kind = 2
+	Client   Kind = 3
+)
+
+// Attribute is a key/value pair describing a span; values are strings, ints
+// and bools
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span is one timed operation of a trace
+type Span struct {
+	Name   string
+	Kind   Kind
+	Parent SpanID
+	SpanContext
+	Start time.Time
+
+	mtx        sync.Mutex
+	end        time.Time
+	attributes []Attribute
+	err        error
+	ended      bool
+}
+
+// SetAttributes adds key/value pairs to the span
+func (s *Span) SetAttributes(kv ...interface{}) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for i := 0; i+1 < len(kv); i += 2 {
+		s.attributes = append(s.attributes, Attribute{fmt.Sprint(kv[i]), kv[i+1]})
+	}
+}
+
+// End finishes the span, failed if err is not nil, and exports it. Only the
+// first call counts.
+func (s *Span) End(err error) {
+	s.mtx.Lock()
+	if s.ended {
+		s.mtx.Unlock()
+		return
+	}
+	s.ended, s.end, s.err = true, time.Now(), err
+	s.mtx.Unlock()
+	if e := exporter.Load(); e != nil {
+		(*e).Export(s)
+	}
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+// FromContext returns the span ctx is in, either started here or received
+func FromContext(ctx context.Context) (SpanContext, bool) {
+	if s, ok := ctx.Value(spanKey{}).(*Span); ok {
+		return s.SpanContext, true
+	}
+	sc, ok := ctx.Value(remoteKey{}).(SpanContext)
+	return sc, ok
+}
+
+// WithRemote returns ctx with sc, received from another process, as the
+// parent of the next span started
+func WithRemote(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// Start starts a span, a child of the one in ctx or the root of a new trace.
+// Lines logged for the returned context carry the trace ID.
+func Start(ctx context.Context, name string, kind Kind) (context.Context, *Span) {
+	s := &Span{Name: name, Kind: kind, Start: time.Now()}
+	local, isLocal := ctx.Value(spanKey{}).(*Span)
+	switch {
+	case isLocal:
+		s.TraceID, s.Parent = local.TraceID, local.SpanID
+	default:
+		if remote, ok := ctx.Value(remoteKey{}).(SpanContext); ok {
+			s.TraceID, s.Parent = remote.TraceID, remote.SpanID
+		} else {
+			s.TraceID = newTraceID()
+		}
+		// children share the trace, the attribute is set once
+		ctx = logging.WithAttrs(ctx, "trace_id", s.TraceID.String())
+	}
+	s.SpanID = newSpanID()
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+func newTraceID() (id TraceID) {
+	for !id.IsValid() {
+		random(id[:])
+	}
+	return id
+}
+
+func newSpanID() (id SpanID) {
+	for !id.IsValid() {
+		random(id[:])
+	}
+	return id
+}
+
+func random(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("tracing: no randomness for IDs: %v", err))
+	}
+}
+
+// Exporter receives every span that ends
+type Exporter interface {
+	Export(*Span)
+}
+
+var exporter atomic.Pointer[Exporter]
+
+// SetExporter sends the spans that end from now on to e, or nowhere if e is nil
+func SetExporter(e Exporter) {
+	if e == nil {
+		exporter.Store(nil)
+		return
+	}
+	exporter.Store(&e)
+}
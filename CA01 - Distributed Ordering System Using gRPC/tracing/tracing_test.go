@@ -0,0 +1,176 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTraceparent(t *testing.T) {
+	const valid = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceparent(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" {
+		t.Errorf("parsed %v %v", sc.TraceID, sc.SpanID)
+	}
+	if got := sc.Traceparent(); got != valid {
+		t.Errorf("formatted %v, want %v", got, valid)
+	}
+	// a later version may add fields
+	if _, err := ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra"); err != nil {
+		t.Errorf("later version: %v", err)
+	}
+
+	for _, bad := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+	} {
+		if _, err := ParseTraceparent(bad); err == nil {
+			t.Errorf("%q parsed", bad)
+		}
+	}
+}
+
+func TestStartJoinsTraces(t *testing.T) {
+	ctx, root := Start(context.Background(), "root", Internal)
+	if !root.TraceID.IsValid() || root.Parent.IsValid() {
+		t.Fatalf("root span %+v", root.SpanContext)
+	}
+	_, child := Start(ctx, "child", Client)
+	if child.TraceID != root.TraceID || child.Parent != root.SpanID || child.SpanID == root.SpanID {
+		t.Errorf("child %+v of %+v", child.SpanContext, root.SpanContext)
+	}
+
+	remote := SpanContext{TraceID: TraceID{1}, SpanID: SpanID{2}}
+	ctx, server := Start(WithRemote(context.Background(), remote), "server", Server)
+	if server.TraceID != remote.TraceID || server.Parent != remote.SpanID {
+		t.Errorf("server %+v of remote %+v", server.SpanContext, remote)
+	}
+	if sc, ok := FromContext(ctx); !ok || sc != server.SpanContext {
+		t.Errorf("context holds %+v", sc)
+	}
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("empty context holds a span")
+	}
+}
+
+// recorder keeps the spans exported
+type recorder struct {
+	spans []*Span
+}
+
+func (r *recorder) Export(s *Span) { r.spans = append(r.spans, s) }
+
+func TestSpansExportedOnce(t *testing.T) {
+	r := &recorder{}
+	SetExporter(r)
+	defer SetExporter(nil)
+	_, span := Start(context.Background(), "op", Internal)
+	span.End(nil)
+	span.End(errors.New("again"))
+	if len(r.spans) != 1 || r.spans[0].err != nil {
+		t.Errorf("exported %v", r.spans)
+	}
+
+	SetExporter(nil)
+	_, span = Start(context.Background(), "dropped", Internal)
+	span.End(nil)
+	if len(r.spans) != 1 {
+		t.Errorf("exported %v spans with no exporter set", len(r.spans))
+	}
+}
+
+func TestFileExporterWritesOTLP(t *testing.T) {
+	var b bytes.Buffer
+	e := NewWriterExporter(&b, "order-test")
+	ctx, parent := Start(context.Background(), "parent", Internal)
+	_, span := Start(ctx, "order_service.OrderService/GetOrder", Client)
+	span.SetAttributes("rpc.system", "grpc", "rpc.grpc.status_code", 5, "retried", true)
+	span.End(errors.New("order not found"))
+	e.Export(span)
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []otlpAttribute
+			}
+			ScopeSpans []struct {
+				Scope otlpScope
+				Spans []map[string]interface{}
+			}
+		}
+	}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("%v in %s", err, b.Bytes())
+	}
+	if len(got.ResourceSpans) != 1 || len(got.ResourceSpans[0].ScopeSpans) != 1 || len(got.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("not one span: %s", b.Bytes())
+	}
+	rs := got.ResourceSpans[0]
+	if a := rs.Resource.Attributes; len(a) != 1 || a[0].Key != "service.name" || *a[0].Value.StringValue != "order-test" {
+		t.Errorf("resource %s", b.Bytes())
+	}
+	s := rs.ScopeSpans[0].Spans[0]
+	for key, want := range map[string]interface{}{
+		"traceId":      parent.TraceID.String(),
+		"spanId":       span.SpanID.String(),
+		"parentSpanId": parent.SpanID.String(),
+		"name":         "order_service.OrderService/GetOrder",
+		"kind":         float64(Client),
+	} {
+		if s[key] != want {
+			t.Errorf("%v is %v, want %v", key, s[key], want)
+		}
+	}
+	if _, ok := s["startTimeUnixNano"].(string); !ok {
+		t.Errorf("start time %v is not a string", s["startTimeUnixNano"])
+	}
+	attrs, _ := json.Marshal(s["attributes"])
+	const wantAttrs = `[{"key":"rpc.system","value":{"stringValue":"grpc"}},{"key":"rpc.grpc.status_code","value":{"intValue":"5"}},{"key":"retried","value":{"boolValue":true}}]`
+	if string(attrs) != wantAttrs {
+		t.Errorf("attributes %s, want %s", attrs, wantAttrs)
+	}
+	status, _ := json.Marshal(s["status"])
+	if string(status) != `{"code":2,"message":"order not found"}` {
+		t.Errorf("status %s", status)
+	}
+}
+
+func TestServerSpanJoinsCaller(t *testing.T) {
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	for _, tt := range []struct {
+		name   string
+		md     metadata.MD
+		joined bool
+	}{
+		{"caller's trace", metadata.Pairs(TraceparentKey, traceparent), true},
+		{"no traceparent", metadata.MD{}, false},
+		{"malformed traceparent", metadata.Pairs(TraceparentKey, "00-xyz"), false},
+	} {
+		ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+		_, span := startServer(ctx, "/order_service.OrderService/GetOrder")
+		caller, _ := ParseTraceparent(traceparent)
+		if joined := span.TraceID == caller.TraceID && span.Parent == caller.SpanID; joined != tt.joined {
+			t.Errorf("%v: span %+v of parent %v", tt.name, span.SpanContext, span.Parent)
+		}
+		if !span.TraceID.IsValid() || span.Kind != Server || span.Name != "order_service.OrderService/GetOrder" {
+			t.Errorf("%v: span %+v", tt.name, span)
+		}
+	}
+}